@@ -1,11 +1,24 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
+	"time"
 
 	_ "github.com/AyKrimino/JobSeekerAPI/docs"
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/service/education"
+	"github.com/AyKrimino/JobSeekerAPI/service/mailer"
+	"github.com/AyKrimino/JobSeekerAPI/service/notification"
+	"github.com/AyKrimino/JobSeekerAPI/service/position"
+	"github.com/AyKrimino/JobSeekerAPI/service/privacy"
+	"github.com/AyKrimino/JobSeekerAPI/service/realtime"
+	"github.com/AyKrimino/JobSeekerAPI/service/resume"
+	"github.com/AyKrimino/JobSeekerAPI/service/review"
+	"github.com/AyKrimino/JobSeekerAPI/service/skill"
+	"github.com/AyKrimino/JobSeekerAPI/service/talent"
 	"github.com/AyKrimino/JobSeekerAPI/service/user"
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -24,12 +37,53 @@ func NewAPIServer(addr string, db *sql.DB) *APIServer {
 }
 
 func (s *APIServer) Run() error {
+	hub := realtime.NewHub()
+	m := mailer.NewMailer()
+	notifier := notification.NewService(
+		notification.NewNotificationStore(s.db),
+		user.NewUserStore(s.db),
+		m,
+		hub,
+	)
+	go notifier.RunDigests(context.Background(), 24*time.Hour)
+	go position.RunExperienceRefresh(context.Background(), position.NewPositionStore(s.db), 24*time.Hour)
+
 	router := mux.NewRouter()
 	subrouter := router.PathPrefix("/api/v1").Subrouter()
 
 	userHandler := user.NewHandler(s.db)
 	userHandler.RegisterRoutes(subrouter)
 
+	positionHandler := position.NewHandler(s.db)
+	positionHandler.RegisterRoutes(subrouter)
+
+	educationHandler := education.NewHandler(s.db)
+	educationHandler.RegisterRoutes(subrouter)
+
+	privacyHandler := privacy.NewHandler(s.db)
+	privacyHandler.RegisterRoutes(subrouter)
+
+	talentHandler := talent.NewHandler(s.db)
+	talentHandler.RegisterRoutes(subrouter)
+
+	resumeHandler := resume.NewHandler(s.db)
+	resumeHandler.RegisterRoutes(subrouter)
+
+	skillHandler := skill.NewHandler(s.db)
+	skillHandler.RegisterRoutes(subrouter)
+
+	notificationHandler := notification.NewHandler(s.db)
+	notificationHandler.RegisterRoutes(subrouter)
+
+	realtimeHandler := realtime.NewHandler(s.db, hub)
+	realtimeHandler.RegisterRoutes(subrouter)
+
+	companyHandler := company.NewHandler(s.db, user.NewUserStore(s.db), m)
+	companyHandler.RegisterRoutes(subrouter)
+
+	reviewHandler := review.NewHandler(s.db, notifier)
+	reviewHandler.RegisterRoutes(subrouter)
+
 	// Swagger docs
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
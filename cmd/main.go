@@ -16,6 +16,10 @@ import (
 // @description API for managing JobSeeker and Company user registrations.
 // @host localhost:8080
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the JWT token.
 func main() {
 	cfg := mysql.Config{
 		User:                 config.Envs.DBUser,
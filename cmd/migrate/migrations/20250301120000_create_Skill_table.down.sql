@@ -0,0 +1 @@
+DROP TABLE IF EXISTS Skill;
\ No newline at end of file
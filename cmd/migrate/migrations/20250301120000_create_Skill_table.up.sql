@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS Skill (
+    id INT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    category VARCHAR(50),
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)
\ No newline at end of file
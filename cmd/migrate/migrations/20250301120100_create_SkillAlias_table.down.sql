@@ -0,0 +1 @@
+DROP TABLE IF EXISTS SkillAlias;
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS SkillAlias (
+    alias VARCHAR(100) PRIMARY KEY,
+    skillID INT UNSIGNED NOT NULL,
+    FOREIGN KEY (skillID) REFERENCES Skill(id) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS JobSeekerSkill;
\ No newline at end of file
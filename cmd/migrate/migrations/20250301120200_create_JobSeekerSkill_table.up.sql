@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS JobSeekerSkill (
+    jobSeekerID INT UNSIGNED NOT NULL,
+    skillID INT UNSIGNED NOT NULL,
+    PRIMARY KEY (jobSeekerID, skillID),
+    INDEX (skillID),
+    FOREIGN KEY (jobSeekerID) REFERENCES JobSeeker(id) ON DELETE CASCADE,
+    FOREIGN KEY (skillID) REFERENCES Skill(id) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM Skill;
\ No newline at end of file
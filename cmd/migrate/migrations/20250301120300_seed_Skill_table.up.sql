@@ -0,0 +1,61 @@
+INSERT IGNORE INTO Skill (name, category) VALUES
+    ('Go', 'Language'),
+    ('Python', 'Language'),
+    ('Java', 'Language'),
+    ('JavaScript', 'Language'),
+    ('TypeScript', 'Language'),
+    ('C', 'Language'),
+    ('C++', 'Language'),
+    ('C#', 'Language'),
+    ('Ruby', 'Language'),
+    ('PHP', 'Language'),
+    ('Rust', 'Language'),
+    ('Kotlin', 'Language'),
+    ('Swift', 'Language'),
+    ('Scala', 'Language'),
+    ('R', 'Language'),
+    ('SQL', 'Language'),
+    ('Bash', 'Language'),
+    ('HTML', 'Language'),
+    ('CSS', 'Language'),
+    ('React', 'Framework'),
+    ('Angular', 'Framework'),
+    ('Vue.js', 'Framework'),
+    ('Node.js', 'Framework'),
+    ('Express', 'Framework'),
+    ('Django', 'Framework'),
+    ('Flask', 'Framework'),
+    ('Spring', 'Framework'),
+    ('.NET', 'Framework'),
+    ('Ruby on Rails', 'Framework'),
+    ('Laravel', 'Framework'),
+    ('TensorFlow', 'Framework'),
+    ('PyTorch', 'Framework'),
+    ('Pandas', 'Framework'),
+    ('MySQL', 'Database'),
+    ('PostgreSQL', 'Database'),
+    ('MongoDB', 'Database'),
+    ('Redis', 'Database'),
+    ('Elasticsearch', 'Database'),
+    ('Kafka', 'Tool'),
+    ('Docker', 'DevOps'),
+    ('Kubernetes', 'DevOps'),
+    ('AWS', 'Cloud'),
+    ('Azure', 'Cloud'),
+    ('GCP', 'Cloud'),
+    ('Terraform', 'DevOps'),
+    ('Linux', 'Tool'),
+    ('Git', 'Tool'),
+    ('CI/CD', 'DevOps'),
+    ('GraphQL', 'Tool'),
+    ('REST', 'Tool'),
+    ('gRPC', 'Tool'),
+    ('Machine Learning', 'Practice'),
+    ('Data Analysis', 'Practice'),
+    ('Agile', 'Practice'),
+    ('Project Management', 'Practice'),
+    ('UI/UX Design', 'Practice'),
+    ('Figma', 'Tool'),
+    ('Excel', 'Tool'),
+    ('Communication', 'Soft Skill'),
+    ('Leadership', 'Soft Skill')
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM SkillAlias;
\ No newline at end of file
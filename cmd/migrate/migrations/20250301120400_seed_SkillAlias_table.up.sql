@@ -0,0 +1,114 @@
+INSERT IGNORE INTO SkillAlias (alias, skillID)
+SELECT v.alias, s.id
+FROM (
+    SELECT 'go' AS alias, 'Go' AS name
+    UNION ALL SELECT 'golang', 'Go'
+    UNION ALL SELECT 'python', 'Python'
+    UNION ALL SELECT 'python3', 'Python'
+    UNION ALL SELECT 'java', 'Java'
+    UNION ALL SELECT 'javascript', 'JavaScript'
+    UNION ALL SELECT 'js', 'JavaScript'
+    UNION ALL SELECT 'ecmascript', 'JavaScript'
+    UNION ALL SELECT 'es6', 'JavaScript'
+    UNION ALL SELECT 'typescript', 'TypeScript'
+    UNION ALL SELECT 'ts', 'TypeScript'
+    UNION ALL SELECT 'c', 'C'
+    UNION ALL SELECT 'c++', 'C++'
+    UNION ALL SELECT 'cpp', 'C++'
+    UNION ALL SELECT 'c#', 'C#'
+    UNION ALL SELECT 'csharp', 'C#'
+    UNION ALL SELECT 'ruby', 'Ruby'
+    UNION ALL SELECT 'php', 'PHP'
+    UNION ALL SELECT 'rust', 'Rust'
+    UNION ALL SELECT 'kotlin', 'Kotlin'
+    UNION ALL SELECT 'swift', 'Swift'
+    UNION ALL SELECT 'scala', 'Scala'
+    UNION ALL SELECT 'r', 'R'
+    UNION ALL SELECT 'sql', 'SQL'
+    UNION ALL SELECT 'bash', 'Bash'
+    UNION ALL SELECT 'shell scripting', 'Bash'
+    UNION ALL SELECT 'shell', 'Bash'
+    UNION ALL SELECT 'html', 'HTML'
+    UNION ALL SELECT 'html5', 'HTML'
+    UNION ALL SELECT 'css', 'CSS'
+    UNION ALL SELECT 'css3', 'CSS'
+    UNION ALL SELECT 'react', 'React'
+    UNION ALL SELECT 'react.js', 'React'
+    UNION ALL SELECT 'reactjs', 'React'
+    UNION ALL SELECT 'angular', 'Angular'
+    UNION ALL SELECT 'angularjs', 'Angular'
+    UNION ALL SELECT 'vue.js', 'Vue.js'
+    UNION ALL SELECT 'vue', 'Vue.js'
+    UNION ALL SELECT 'vuejs', 'Vue.js'
+    UNION ALL SELECT 'node.js', 'Node.js'
+    UNION ALL SELECT 'nodejs', 'Node.js'
+    UNION ALL SELECT 'node', 'Node.js'
+    UNION ALL SELECT 'express', 'Express'
+    UNION ALL SELECT 'express.js', 'Express'
+    UNION ALL SELECT 'expressjs', 'Express'
+    UNION ALL SELECT 'django', 'Django'
+    UNION ALL SELECT 'flask', 'Flask'
+    UNION ALL SELECT 'spring', 'Spring'
+    UNION ALL SELECT 'spring boot', 'Spring'
+    UNION ALL SELECT 'spring framework', 'Spring'
+    UNION ALL SELECT '.net', '.NET'
+    UNION ALL SELECT 'dotnet', '.NET'
+    UNION ALL SELECT 'asp.net', '.NET'
+    UNION ALL SELECT 'ruby on rails', 'Ruby on Rails'
+    UNION ALL SELECT 'rails', 'Ruby on Rails'
+    UNION ALL SELECT 'laravel', 'Laravel'
+    UNION ALL SELECT 'tensorflow', 'TensorFlow'
+    UNION ALL SELECT 'pytorch', 'PyTorch'
+    UNION ALL SELECT 'pandas', 'Pandas'
+    UNION ALL SELECT 'mysql', 'MySQL'
+    UNION ALL SELECT 'postgresql', 'PostgreSQL'
+    UNION ALL SELECT 'postgres', 'PostgreSQL'
+    UNION ALL SELECT 'mongodb', 'MongoDB'
+    UNION ALL SELECT 'mongo', 'MongoDB'
+    UNION ALL SELECT 'redis', 'Redis'
+    UNION ALL SELECT 'elasticsearch', 'Elasticsearch'
+    UNION ALL SELECT 'elastic search', 'Elasticsearch'
+    UNION ALL SELECT 'kafka', 'Kafka'
+    UNION ALL SELECT 'apache kafka', 'Kafka'
+    UNION ALL SELECT 'docker', 'Docker'
+    UNION ALL SELECT 'kubernetes', 'Kubernetes'
+    UNION ALL SELECT 'k8s', 'Kubernetes'
+    UNION ALL SELECT 'aws', 'AWS'
+    UNION ALL SELECT 'amazon web services', 'AWS'
+    UNION ALL SELECT 'azure', 'Azure'
+    UNION ALL SELECT 'microsoft azure', 'Azure'
+    UNION ALL SELECT 'gcp', 'GCP'
+    UNION ALL SELECT 'google cloud', 'GCP'
+    UNION ALL SELECT 'google cloud platform', 'GCP'
+    UNION ALL SELECT 'terraform', 'Terraform'
+    UNION ALL SELECT 'linux', 'Linux'
+    UNION ALL SELECT 'git', 'Git'
+    UNION ALL SELECT 'ci/cd', 'CI/CD'
+    UNION ALL SELECT 'continuous integration', 'CI/CD'
+    UNION ALL SELECT 'graphql', 'GraphQL'
+    UNION ALL SELECT 'rest', 'REST'
+    UNION ALL SELECT 'rest api', 'REST'
+    UNION ALL SELECT 'rest apis', 'REST'
+    UNION ALL SELECT 'restful', 'REST'
+    UNION ALL SELECT 'grpc', 'gRPC'
+    UNION ALL SELECT 'machine learning', 'Machine Learning'
+    UNION ALL SELECT 'ml', 'Machine Learning'
+    UNION ALL SELECT 'data analysis', 'Data Analysis'
+    UNION ALL SELECT 'data analytics', 'Data Analysis'
+    UNION ALL SELECT 'agile', 'Agile'
+    UNION ALL SELECT 'scrum', 'Agile'
+    UNION ALL SELECT 'kanban', 'Agile'
+    UNION ALL SELECT 'project management', 'Project Management'
+    UNION ALL SELECT 'ui/ux design', 'UI/UX Design'
+    UNION ALL SELECT 'ui/ux', 'UI/UX Design'
+    UNION ALL SELECT 'ux design', 'UI/UX Design'
+    UNION ALL SELECT 'ui design', 'UI/UX Design'
+    UNION ALL SELECT 'figma', 'Figma'
+    UNION ALL SELECT 'excel', 'Excel'
+    UNION ALL SELECT 'microsoft excel', 'Excel'
+    UNION ALL SELECT 'communication', 'Communication'
+    UNION ALL SELECT 'communication skills', 'Communication'
+    UNION ALL SELECT 'leadership', 'Leadership'
+    UNION ALL SELECT 'team leadership', 'Leadership'
+) AS v
+JOIN Skill s ON s.name = v.name
\ No newline at end of file
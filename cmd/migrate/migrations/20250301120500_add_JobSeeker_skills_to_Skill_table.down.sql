@@ -0,0 +1 @@
+DELETE FROM Skill WHERE id NOT IN (SELECT skillID FROM SkillAlias);
\ No newline at end of file
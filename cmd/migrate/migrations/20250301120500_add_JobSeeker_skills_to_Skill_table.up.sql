@@ -0,0 +1,6 @@
+INSERT IGNORE INTO Skill (name)
+SELECT DISTINCT TRIM(REGEXP_REPLACE(j.skill, '[[:space:]]+', ' '))
+FROM JobSeeker js
+JOIN JSON_TABLE(js.skills, '$[*]' COLUMNS (skill VARCHAR(100) PATH '$')) AS j
+LEFT JOIN SkillAlias a ON a.alias = LOWER(TRIM(REGEXP_REPLACE(j.skill, '[[:space:]]+', ' ')))
+WHERE a.skillID IS NULL AND TRIM(REGEXP_REPLACE(j.skill, '[[:space:]]+', ' ')) <> ''
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM SkillAlias WHERE skillID NOT IN (SELECT id FROM Skill WHERE category IS NOT NULL);
\ No newline at end of file
@@ -0,0 +1,5 @@
+INSERT IGNORE INTO SkillAlias (alias, skillID)
+SELECT LOWER(s.name), s.id
+FROM Skill s
+LEFT JOIN SkillAlias a ON a.alias = LOWER(s.name)
+WHERE a.skillID IS NULL
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM JobSeekerSkill;
\ No newline at end of file
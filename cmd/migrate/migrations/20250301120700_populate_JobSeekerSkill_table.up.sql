@@ -0,0 +1,5 @@
+INSERT IGNORE INTO JobSeekerSkill (jobSeekerID, skillID)
+SELECT js.id, a.skillID
+FROM JobSeeker js
+JOIN JSON_TABLE(js.skills, '$[*]' COLUMNS (skill VARCHAR(100) PATH '$')) AS j
+JOIN SkillAlias a ON a.alias = LOWER(TRIM(REGEXP_REPLACE(j.skill, '[[:space:]]+', ' ')))
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS Notification;
\ No newline at end of file
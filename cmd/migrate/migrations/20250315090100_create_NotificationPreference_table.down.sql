@@ -0,0 +1 @@
+DROP TABLE IF EXISTS NotificationPreference;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS NotificationPreference (
+    userID INT UNSIGNED NOT NULL,
+    eventType VARCHAR(50) NOT NULL,
+    inApp BOOLEAN NOT NULL DEFAULT TRUE,
+    email BOOLEAN NOT NULL DEFAULT FALSE,
+    digest BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (userID, eventType),
+    FOREIGN KEY (userID) REFERENCES User(id) ON DELETE CASCADE
+)
\ No newline at end of file
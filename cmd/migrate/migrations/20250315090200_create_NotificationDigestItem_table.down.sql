@@ -0,0 +1 @@
+DROP TABLE IF EXISTS NotificationDigestItem;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS NotificationDigestItem (
+    id INT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    userID INT UNSIGNED NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT,
+    link VARCHAR(255),
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    sentAt TIMESTAMP NULL DEFAULT NULL,
+    INDEX (sentAt, userID),
+    FOREIGN KEY (userID) REFERENCES User(id) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS CompanyReview;
\ No newline at end of file
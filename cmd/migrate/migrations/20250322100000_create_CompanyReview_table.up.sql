@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS CompanyReview (
+    id INT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    companyID INT UNSIGNED NOT NULL,
+    userID INT UNSIGNED NOT NULL,
+    overallRating TINYINT UNSIGNED NOT NULL,
+    cultureRating TINYINT UNSIGNED NOT NULL,
+    payRating TINYINT UNSIGNED NOT NULL,
+    managementRating TINYINT UNSIGNED NOT NULL,
+    pros TEXT,
+    cons TEXT,
+    isAnonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    status ENUM('Pending', 'Approved', 'Rejected') NOT NULL DEFAULT 'Pending',
+    moderationNote VARCHAR(255),
+    moderatedBy INT UNSIGNED,
+    moderatedAt TIMESTAMP NULL DEFAULT NULL,
+    reply TEXT,
+    repliedAt TIMESTAMP NULL DEFAULT NULL,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updatedAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE (companyID, userID),
+    INDEX (status, createdAt),
+    FOREIGN KEY (companyID) REFERENCES Company(id) ON DELETE CASCADE,
+    FOREIGN KEY (userID) REFERENCES User(id) ON DELETE CASCADE,
+    FOREIGN KEY (moderatedBy) REFERENCES User(id) ON DELETE SET NULL
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS Position;
\ No newline at end of file
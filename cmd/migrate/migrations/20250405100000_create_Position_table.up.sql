@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS Position (
+    id INT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    jobSeekerID INT UNSIGNED NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    employer VARCHAR(255) NOT NULL,
+    startDate DATE NOT NULL,
+    endDate DATE,
+    description TEXT,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updatedAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX (jobSeekerID, startDate),
+    FOREIGN KEY (jobSeekerID) REFERENCES JobSeeker(id) ON DELETE CASCADE
+)
\ No newline at end of file
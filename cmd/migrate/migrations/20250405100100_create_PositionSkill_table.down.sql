@@ -0,0 +1 @@
+DROP TABLE IF EXISTS PositionSkill;
\ No newline at end of file
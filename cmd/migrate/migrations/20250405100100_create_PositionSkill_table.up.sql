@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS PositionSkill (
+    positionID INT UNSIGNED NOT NULL,
+    skillID INT UNSIGNED NOT NULL,
+    PRIMARY KEY (positionID, skillID),
+    INDEX (skillID),
+    FOREIGN KEY (positionID) REFERENCES Position(id) ON DELETE CASCADE,
+    FOREIGN KEY (skillID) REFERENCES Skill(id) ON DELETE CASCADE
+)
\ No newline at end of file
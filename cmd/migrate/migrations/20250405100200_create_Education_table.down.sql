@@ -0,0 +1 @@
+DROP TABLE IF EXISTS Education;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS Education (
+    id INT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    jobSeekerID INT UNSIGNED NOT NULL,
+    institution VARCHAR(255) NOT NULL,
+    degree VARCHAR(255),
+    fieldOfStudy VARCHAR(255),
+    startDate DATE,
+    endDate DATE,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updatedAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX (jobSeekerID),
+    FOREIGN KEY (jobSeekerID) REFERENCES JobSeeker(id) ON DELETE CASCADE
+)
\ No newline at end of file
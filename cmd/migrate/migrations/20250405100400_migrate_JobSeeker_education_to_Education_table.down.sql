@@ -0,0 +1 @@
+DELETE FROM Education WHERE institution = '';
\ No newline at end of file
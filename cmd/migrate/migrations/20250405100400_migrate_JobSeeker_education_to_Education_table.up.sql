@@ -0,0 +1,4 @@
+INSERT INTO Education (jobSeekerID, institution, degree)
+SELECT id, '', TRIM(education)
+FROM JobSeeker
+WHERE TRIM(education) <> ''
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS CompanyMember;
\ No newline at end of file
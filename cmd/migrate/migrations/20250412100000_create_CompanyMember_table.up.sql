@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS CompanyMember (
+    companyID INT UNSIGNED NOT NULL,
+    userID INT UNSIGNED NOT NULL,
+    role ENUM('owner', 'admin', 'recruiter', 'viewer') NOT NULL,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (companyID, userID),
+    UNIQUE (userID),
+    FOREIGN KEY (companyID) REFERENCES Company(id) ON DELETE CASCADE,
+    FOREIGN KEY (userID) REFERENCES User(id) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM CompanyMember;
\ No newline at end of file
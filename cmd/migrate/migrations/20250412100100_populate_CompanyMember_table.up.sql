@@ -0,0 +1,4 @@
+INSERT IGNORE INTO CompanyMember (companyID, userID, role)
+SELECT id, userId, 'owner'
+FROM Company
+WHERE userId IS NOT NULL
\ No newline at end of file
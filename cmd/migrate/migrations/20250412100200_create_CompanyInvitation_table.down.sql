@@ -0,0 +1 @@
+DROP TABLE IF EXISTS CompanyInvitation;
\ No newline at end of file
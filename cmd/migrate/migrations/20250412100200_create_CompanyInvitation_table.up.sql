@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS CompanyInvitation (
+    id INT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    companyID INT UNSIGNED NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role ENUM('admin', 'recruiter', 'viewer') NOT NULL,
+    tokenHash CHAR(64) NOT NULL UNIQUE,
+    invitedBy INT UNSIGNED,
+    expiresAt TIMESTAMP NOT NULL,
+    acceptedAt TIMESTAMP NULL DEFAULT NULL,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX (companyID, acceptedAt),
+    FOREIGN KEY (companyID) REFERENCES Company(id) ON DELETE CASCADE,
+    FOREIGN KEY (invitedBy) REFERENCES User(id) ON DELETE SET NULL
+)
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE JobSeeker DROP COLUMN visibility, DROP COLUMN openToWork;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE JobSeeker
+    ADD COLUMN visibility ENUM('public', 'companies', 'hidden') NOT NULL DEFAULT 'companies',
+    ADD COLUMN openToWork BOOLEAN NOT NULL DEFAULT FALSE
\ No newline at end of file
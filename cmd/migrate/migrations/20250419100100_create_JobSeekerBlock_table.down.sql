@@ -0,0 +1 @@
+DROP TABLE IF EXISTS JobSeekerBlock;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS JobSeekerBlock (
+    id INT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    jobSeekerID INT UNSIGNED NOT NULL,
+    companyID INT UNSIGNED,
+    emailDomain VARCHAR(255),
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (jobSeekerID, companyID),
+    UNIQUE (jobSeekerID, emailDomain),
+    CHECK ((companyID IS NULL) <> (emailDomain IS NULL)),
+    FOREIGN KEY (jobSeekerID) REFERENCES JobSeeker(id) ON DELETE CASCADE,
+    FOREIGN KEY (companyID) REFERENCES Company(id) ON DELETE CASCADE
+)
\ No newline at end of file
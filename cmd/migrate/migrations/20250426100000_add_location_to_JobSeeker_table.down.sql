@@ -0,0 +1 @@
+ALTER TABLE JobSeeker DROP COLUMN location;
\ No newline at end of file
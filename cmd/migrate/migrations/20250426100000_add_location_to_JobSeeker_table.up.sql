@@ -0,0 +1,3 @@
+ALTER TABLE JobSeeker
+    ADD COLUMN location VARCHAR(100),
+    ADD INDEX (location)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS TalentPool;
\ No newline at end of file
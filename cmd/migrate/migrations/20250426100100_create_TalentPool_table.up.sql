@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS TalentPool (
+    id INT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    companyID INT UNSIGNED NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    createdBy INT UNSIGNED,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (companyID, name),
+    FOREIGN KEY (companyID) REFERENCES Company(id) ON DELETE CASCADE,
+    FOREIGN KEY (createdBy) REFERENCES User(id) ON DELETE SET NULL
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS TalentPoolCandidate;
\ No newline at end of file
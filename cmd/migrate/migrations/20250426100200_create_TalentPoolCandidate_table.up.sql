@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS TalentPoolCandidate (
+    poolID INT UNSIGNED NOT NULL,
+    jobSeekerID INT UNSIGNED NOT NULL,
+    addedBy INT UNSIGNED,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (poolID, jobSeekerID),
+    INDEX (jobSeekerID),
+    FOREIGN KEY (poolID) REFERENCES TalentPool(id) ON DELETE CASCADE,
+    FOREIGN KEY (jobSeekerID) REFERENCES JobSeeker(id) ON DELETE CASCADE,
+    FOREIGN KEY (addedBy) REFERENCES User(id) ON DELETE SET NULL
+)
\ No newline at end of file
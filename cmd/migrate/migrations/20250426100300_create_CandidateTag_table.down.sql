@@ -0,0 +1 @@
+DROP TABLE IF EXISTS CandidateTag;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS CandidateTag (
+    companyID INT UNSIGNED NOT NULL,
+    jobSeekerID INT UNSIGNED NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (companyID, jobSeekerID, tag),
+    INDEX (companyID, tag),
+    FOREIGN KEY (companyID) REFERENCES Company(id) ON DELETE CASCADE,
+    FOREIGN KEY (jobSeekerID) REFERENCES JobSeeker(id) ON DELETE CASCADE
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS CandidateNote;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS CandidateNote (
+    id INT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    companyID INT UNSIGNED NOT NULL,
+    jobSeekerID INT UNSIGNED NOT NULL,
+    authorID INT UNSIGNED,
+    body TEXT NOT NULL,
+    createdAt TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX (companyID, jobSeekerID, createdAt),
+    FOREIGN KEY (companyID) REFERENCES Company(id) ON DELETE CASCADE,
+    FOREIGN KEY (jobSeekerID) REFERENCES JobSeeker(id) ON DELETE CASCADE,
+    FOREIGN KEY (authorID) REFERENCES User(id) ON DELETE SET NULL
+)
\ No newline at end of file
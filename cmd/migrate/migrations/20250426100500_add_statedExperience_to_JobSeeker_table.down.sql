@@ -0,0 +1 @@
+ALTER TABLE JobSeeker DROP COLUMN statedExperience;
\ No newline at end of file
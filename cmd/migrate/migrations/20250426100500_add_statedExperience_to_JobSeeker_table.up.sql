@@ -0,0 +1 @@
+ALTER TABLE JobSeeker ADD COLUMN statedExperience INT NOT NULL DEFAULT 0
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE JobSeeker SET statedExperience = 0;
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE JobSeeker SET statedExperience = COALESCE(experience, 0)
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE SkillAlias DROP COLUMN ambiguous;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE SkillAlias ADD COLUMN ambiguous BOOLEAN NOT NULL DEFAULT FALSE
\ No newline at end of file
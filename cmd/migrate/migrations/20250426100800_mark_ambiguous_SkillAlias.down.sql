@@ -0,0 +1 @@
+UPDATE SkillAlias SET ambiguous = FALSE;
\ No newline at end of file
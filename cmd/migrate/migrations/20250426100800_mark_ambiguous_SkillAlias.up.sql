@@ -0,0 +1,5 @@
+UPDATE SkillAlias SET ambiguous = TRUE
+WHERE alias IN (
+    'go', 'c', 'r', 'rust', 'swift', 'shell', 'react', 'angular', 'node', 'express',
+    'flask', 'spring', 'rails', 'pandas', 'rest', 'ml', 'agile', 'excel'
+)
\ No newline at end of file
@@ -21,6 +21,11 @@ type Config struct {
 	TestDBName     string
 	TestDBAddress  string
 	TestDBPassword string
+	SMTPHost       string
+	SMTPPort       string
+	SMTPUser       string
+	SMTPPassword   string
+	MailFrom       string
 }
 
 var Envs = initConfig()
@@ -59,6 +64,11 @@ func initConfig() Config {
 			getEnv("TEST_DB_PORT", "3306"),
 		),
 		TestDBPassword: getEnv("TEST_DB_PASSWORD", "admin"),
+		SMTPHost:       getEnv("SMTP_HOST", ""),
+		SMTPPort:       getEnv("SMTP_PORT", "587"),
+		SMTPUser:       getEnv("SMTP_USER", ""),
+		SMTPPassword:   getEnv("SMTP_PASSWORD", ""),
+		MailFrom:       getEnv("MAIL_FROM", "no-reply@jobseeker.local"),
 	}
 }
 
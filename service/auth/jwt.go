@@ -1,13 +1,27 @@
 package auth
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/AyKrimino/JobSeekerAPI/config"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+type contextKey string
+
+const (
+	UserKey     contextKey = "userID"
+	UserRoleKey contextKey = "userRole"
+)
+
 func CreateJWT(userID int, secret []byte) (string, error) {
 	if len(secret) == 0 {
 		return "", fmt.Errorf("secret key cannot be empty")
@@ -46,3 +60,74 @@ func ValidateJWT(tokenString string, secret []byte) (*jwt.Token, jwt.MapClaims,
 
 	return nil, nil, fmt.Errorf("invalid token")
 }
+
+// WithJWTAuth rejects requests without a valid bearer token and stores the
+// authenticated user's ID and role in the request context.
+func WithJWTAuth(handlerFunc http.HandlerFunc, store types.UserRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tokenString := getTokenFromRequest(r)
+
+		_, claims, err := ValidateJWT(tokenString, []byte(config.Envs.JWTSecret))
+		if err != nil {
+			log.Printf("failed to validate token: %v", err)
+			permissionDenied(w)
+			return
+		}
+
+		str, ok := claims["userID"].(string)
+		if !ok {
+			permissionDenied(w)
+			return
+		}
+
+		userID, err := strconv.Atoi(str)
+		if err != nil {
+			permissionDenied(w)
+			return
+		}
+
+		u, err := store.GetUserByID(userID)
+		if err != nil || !u.IsActive {
+			permissionDenied(w)
+			return
+		}
+
+		ctx := r.Context()
+		ctx = context.WithValue(ctx, UserKey, u.ID)
+		ctx = context.WithValue(ctx, UserRoleKey, u.Role)
+		r = r.WithContext(ctx)
+
+		handlerFunc(w, r)
+	}
+}
+
+func GetUserIDFromContext(ctx context.Context) int {
+	userID, ok := ctx.Value(UserKey).(int)
+	if !ok {
+		return -1
+	}
+
+	return userID
+}
+
+func GetUserRoleFromContext(ctx context.Context) string {
+	role, ok := ctx.Value(UserRoleKey).(string)
+	if !ok {
+		return ""
+	}
+
+	return role
+}
+
+func getTokenFromRequest(r *http.Request) string {
+	tokenAuth := r.Header.Get("Authorization")
+	if tokenAuth != "" {
+		return strings.TrimPrefix(tokenAuth, "Bearer ")
+	}
+
+	return ""
+}
+
+func permissionDenied(w http.ResponseWriter) {
+	utils.WriteError(w, http.StatusForbidden, fmt.Errorf("permission denied"))
+}
@@ -0,0 +1,481 @@
+package company
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/config"
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+const invitationTTL = 7 * 24 * time.Hour
+
+func (h *Handler) registerMemberRoutes(router *mux.Router) {
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/members",
+		auth.WithJWTAuth(h.handleGetMembers, h.UserRepo),
+	).Methods("GET")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/members/{userID:[0-9]+}",
+		auth.WithJWTAuth(h.handleUpdateMember, h.UserRepo),
+	).Methods("PATCH")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/members/{userID:[0-9]+}",
+		auth.WithJWTAuth(h.handleRemoveMember, h.UserRepo),
+	).Methods("DELETE")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/owner",
+		auth.WithJWTAuth(h.handleTransferOwnership, h.UserRepo),
+	).Methods("POST")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/invitations",
+		auth.WithJWTAuth(h.handleGetInvitations, h.UserRepo),
+	).Methods("GET")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/invitations",
+		auth.WithJWTAuth(h.handleCreateInvitation, h.UserRepo),
+	).Methods("POST")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/invitations/{invitationID:[0-9]+}",
+		auth.WithJWTAuth(h.handleRevokeInvitation, h.UserRepo),
+	).Methods("DELETE")
+	router.HandleFunc("/invitations/accept", h.handleAcceptInvitation).Methods("POST")
+}
+
+// @Summary List company members
+// @Description List the company's team. Any member may view it.
+// @Tags companies
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Success 200 {array} types.CompanyMember "Members"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/members [get]
+func (h *Handler) handleGetMembers(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, RoleViewer)
+	if !ok {
+		return
+	}
+
+	members, err := h.MemberRepo.GetMembers(actor.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, members)
+}
+
+// @Summary Change a member's role
+// @Description Change the role of a member ranked below the caller, to a role below the caller's. Owners and admins only.
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Param userID path int true "Member user ID"
+// @Param member body types.UpdateMemberRequest true "New role"
+// @Success 200 {object} types.CompanyMember "Updated member"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/members/{userID} [patch]
+func (h *Handler) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, RoleAdmin)
+	if !ok {
+		return
+	}
+
+	target, ok := h.targetMemberFromPath(w, r, actor)
+	if !ok {
+		return
+	}
+
+	var req types.UpdateMemberRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	if !Outranks(actor.Role, target.Role) || !Outranks(actor.Role, req.Role) {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("permission denied"))
+		return
+	}
+
+	if err := h.MemberRepo.UpdateMemberRole(actor.CompanyID, target.UserID, req.Role); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	target.Role = req.Role
+	utils.WriteJSON(w, http.StatusOK, target)
+}
+
+// @Summary Remove a member
+// @Description Remove a member ranked below the caller, or leave the company. The owner must transfer ownership before leaving.
+// @Tags companies
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Param userID path int true "Member user ID"
+// @Success 200 {object} types.SuccessResponse "Member removed"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/members/{userID} [delete]
+func (h *Handler) handleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, RoleViewer)
+	if !ok {
+		return
+	}
+
+	target, ok := h.targetMemberFromPath(w, r, actor)
+	if !ok {
+		return
+	}
+
+	if target.Role == RoleOwner {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("transfer ownership before removing the owner"))
+		return
+	}
+
+	leaving := target.UserID == actor.UserID
+	if !leaving && (!HasRole(actor, RoleAdmin) || !Outranks(actor.Role, target.Role)) {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("permission denied"))
+		return
+	}
+
+	if err := h.MemberRepo.RemoveMember(actor.CompanyID, target.UserID); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Member removed"})
+}
+
+// @Summary Transfer ownership
+// @Description Make another member the owner. The current owner becomes an admin. Owner only.
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Param owner body types.TransferOwnershipRequest true "New owner"
+// @Success 200 {object} types.SuccessResponse "Ownership transferred"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/owner [post]
+func (h *Handler) handleTransferOwnership(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, RoleOwner)
+	if !ok {
+		return
+	}
+
+	var req types.TransferOwnershipRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	if req.UserID == actor.UserID {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("you already own this company"))
+		return
+	}
+
+	if _, err := h.MemberRepo.GetMember(actor.CompanyID, req.UserID); err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+
+	if err := h.MemberRepo.TransferOwnership(actor.CompanyID, actor.UserID, req.UserID); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Ownership transferred"})
+}
+
+// @Summary List pending invitations
+// @Tags companies
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Success 200 {array} types.CompanyInvitation "Pending invitations"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/invitations [get]
+func (h *Handler) handleGetInvitations(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, RoleAdmin)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.MemberRepo.GetPendingInvitations(actor.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, invitations)
+}
+
+// @Summary Invite a member
+// @Description Email an invitation to join the company team with a role below the caller's. Invitations expire after 7 days. Owners and admins only.
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Param invitation body types.CreateInvitationRequest true "Invitation"
+// @Success 201 {object} types.CompanyInvitation "Invitation sent"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/invitations [post]
+func (h *Handler) handleCreateInvitation(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, RoleAdmin)
+	if !ok {
+		return
+	}
+
+	var req types.CreateInvitationRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	if !Outranks(actor.Role, req.Role) {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("permission denied"))
+		return
+	}
+
+	if _, err := h.UserRepo.GetUserByEmail(req.Email); err == nil {
+		utils.WriteError(w, http.StatusConflict, fmt.Errorf("user with email %s already exists", req.Email))
+		return
+	}
+
+	cpy, err := h.CompanyRepo.GetCompanyByID(actor.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	inv := &types.CompanyInvitation{
+		CompanyID: cpy.ID,
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: actor.UserID,
+		ExpiresAt: time.Now().UTC().Add(invitationTTL).Truncate(time.Second),
+	}
+	if err := h.MemberRepo.CreateInvitation(inv, tokenHash); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	subject := fmt.Sprintf("You're invited to join %s on JobSeeker", cpy.Name)
+	body := fmt.Sprintf(
+		"%s invited you to join %s as %s.\n\nTo accept, choose a password and send it with this token to %s:%s/api/v1/invitations/accept before %s:\n\n%s",
+		actor.Email,
+		cpy.Name,
+		req.Role,
+		config.Envs.PublicHost,
+		config.Envs.Port,
+		inv.ExpiresAt.Format(time.RFC1123),
+		token,
+	)
+	if err := h.Mailer.Send(req.Email, subject, body); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to send invitation: %w", err))
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, inv)
+}
+
+// @Summary Revoke an invitation
+// @Tags companies
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Param invitationID path int true "Invitation ID"
+// @Success 200 {object} types.SuccessResponse "Invitation revoked"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Router /api/v1/companies/{companyID}/invitations/{invitationID} [delete]
+func (h *Handler) handleRevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, RoleAdmin)
+	if !ok {
+		return
+	}
+
+	invitationID, err := strconv.Atoi(mux.Vars(r)["invitationID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid invitation id"))
+		return
+	}
+
+	if err := h.MemberRepo.RevokeInvitation(actor.CompanyID, invitationID); err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Invitation revoked"})
+}
+
+// @Summary Accept an invitation
+// @Description Create a company account for the invited email address and join the team with the invited role.
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param invitation body types.AcceptInvitationRequest true "Token from the invitation email and a password for the new account"
+// @Success 201 {object} types.SuccessResponse "Invitation accepted"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 410 {object} map[string]string "Gone"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/invitations/accept [post]
+func (h *Handler) handleAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var req types.AcceptInvitationRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	inv, err := h.MemberRepo.GetInvitationByTokenHash(hashInvitationToken(req.Token))
+	if err == nil && (inv.AcceptedAt != nil || time.Now().After(inv.ExpiresAt)) {
+		err = ErrInvitationUnavailable
+	}
+	if errors.Is(err, ErrInvitationUnavailable) {
+		utils.WriteError(w, http.StatusGone, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if _, err := h.UserRepo.GetUserByEmail(inv.Email); err == nil {
+		utils.WriteError(w, http.StatusConflict, fmt.Errorf("user with email %s already exists", inv.Email))
+		return
+	}
+
+	hashedPassword, err := auth.HashPassword(req.Password)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err = h.MemberRepo.AcceptInvitation(inv, hashedPassword)
+	if errors.Is(err, ErrInvitationUnavailable) {
+		utils.WriteError(w, http.StatusGone, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, types.SuccessResponse{Message: "Invitation accepted"})
+}
+
+// memberFromContext returns the caller's seat on the company in the URL,
+// answering 403 unless they hold at least minRole.
+func (h *Handler) memberFromContext(
+	w http.ResponseWriter,
+	r *http.Request,
+	minRole string,
+) (*types.CompanyMember, bool) {
+	companyID, err := strconv.Atoi(mux.Vars(r)["companyID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid company id"))
+		return nil, false
+	}
+
+	m, err := h.MemberRepo.GetMember(companyID, auth.GetUserIDFromContext(r.Context()))
+	if err != nil || !HasRole(m, minRole) {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("permission denied"))
+		return nil, false
+	}
+
+	return m, true
+}
+
+func (h *Handler) targetMemberFromPath(
+	w http.ResponseWriter,
+	r *http.Request,
+	actor *types.CompanyMember,
+) (*types.CompanyMember, bool) {
+	userID, err := strconv.Atoi(mux.Vars(r)["userID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid user id"))
+		return nil, false
+	}
+
+	m, err := h.MemberRepo.GetMember(actor.CompanyID, userID)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+
+	return m, true
+}
+
+// newInvitationToken returns a random token for the invitation email and
+// the hash stored in its place.
+func newInvitationToken() (token, tokenHash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token = hex.EncodeToString(b)
+	return token, hashInvitationToken(token), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,368 @@
+package company
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+const (
+	RoleOwner     = "owner"
+	RoleAdmin     = "admin"
+	RoleRecruiter = "recruiter"
+	RoleViewer    = "viewer"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer:    1,
+	RoleRecruiter: 2,
+	RoleAdmin:     3,
+	RoleOwner:     4,
+}
+
+var ErrInvitationUnavailable = errors.New("invitation is invalid, expired or already accepted")
+
+// HasRole reports whether m holds minRole or a higher role.
+func HasRole(m *types.CompanyMember, minRole string) bool {
+	return m != nil && RoleAtLeast(m.Role, minRole)
+}
+
+func RoleAtLeast(role, minRole string) bool {
+	return roleRanks[role] >= roleRanks[minRole]
+}
+
+// Outranks reports whether role is strictly higher than other. Members may
+// only manage seats below their own.
+func Outranks(role, other string) bool {
+	return roleRanks[role] > roleRanks[other]
+}
+
+type memberStore struct {
+	db *sql.DB
+}
+
+func NewMemberStore(db *sql.DB) types.CompanyMemberRepository {
+	return &memberStore{
+		db: db,
+	}
+}
+
+func (s *memberStore) GetMember(companyID, userID int) (*types.CompanyMember, error) {
+	rows, err := s.db.Query(selectMember+" WHERE m.companyID = ? AND m.userID = ?", companyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	m := new(types.CompanyMember)
+	for rows.Next() {
+		m, err = scanRowsIntoMember(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if m.UserID == 0 {
+		return nil, fmt.Errorf("member not found")
+	}
+
+	return m, nil
+}
+
+// GetMembershipByUserID returns the user's seat, or nil if they do not
+// belong to any company.
+func (s *memberStore) GetMembershipByUserID(userID int) (*types.CompanyMember, error) {
+	rows, err := s.db.Query(selectMember+" WHERE m.userID = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var m *types.CompanyMember
+	for rows.Next() {
+		m, err = scanRowsIntoMember(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, rows.Err()
+}
+
+func (s *memberStore) GetMembers(companyID int) ([]types.CompanyMember, error) {
+	rows, err := s.db.Query(
+		selectMember+` WHERE m.companyID = ?
+		ORDER BY FIELD(m.role, 'owner', 'admin', 'recruiter', 'viewer'), u.email`,
+		companyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]types.CompanyMember, 0)
+	for rows.Next() {
+		m, err := scanRowsIntoMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, *m)
+	}
+
+	return members, rows.Err()
+}
+
+func (s *memberStore) UpdateMemberRole(companyID, userID int, role string) error {
+	_, err := s.db.Exec(
+		"UPDATE CompanyMember SET role = ? WHERE companyID = ? AND userID = ? AND role <> 'owner'",
+		role,
+		companyID,
+		userID,
+	)
+
+	return err
+}
+
+func (s *memberStore) RemoveMember(companyID, userID int) error {
+	_, err := s.db.Exec(
+		"DELETE FROM CompanyMember WHERE companyID = ? AND userID = ? AND role <> 'owner'",
+		companyID,
+		userID,
+	)
+
+	return err
+}
+
+// TransferOwnership makes toUserID the owner and demotes the previous owner
+// to admin. Company.userId follows the owner.
+func (s *memberStore) TransferOwnership(companyID, fromUserID, toUserID int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		"UPDATE CompanyMember SET role = 'admin' WHERE companyID = ? AND userID = ? AND role = 'owner'",
+		companyID,
+		fromUserID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return fmt.Errorf("only the owner can transfer ownership")
+	}
+
+	res, err = tx.Exec(
+		"UPDATE CompanyMember SET role = 'owner' WHERE companyID = ? AND userID = ?",
+		companyID,
+		toUserID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return fmt.Errorf("member not found")
+	}
+
+	if _, err := tx.Exec("UPDATE Company SET userId = ? WHERE id = ?", toUserID, companyID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *memberStore) CreateInvitation(inv *types.CompanyInvitation, tokenHash string) error {
+	res, err := s.db.Exec(
+		"INSERT INTO CompanyInvitation (companyID, email, role, tokenHash, invitedBy, expiresAt) VALUES (?, ?, ?, ?, ?, ?)",
+		inv.CompanyID,
+		inv.Email,
+		inv.Role,
+		tokenHash,
+		inv.InvitedBy,
+		inv.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	inv.ID = int(id)
+
+	return nil
+}
+
+func (s *memberStore) GetPendingInvitations(companyID int) ([]types.CompanyInvitation, error) {
+	rows, err := s.db.Query(
+		selectInvitation+` WHERE companyID = ? AND acceptedAt IS NULL AND expiresAt > UTC_TIMESTAMP()
+		ORDER BY createdAt DESC, id DESC`,
+		companyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := make([]types.CompanyInvitation, 0)
+	for rows.Next() {
+		inv, err := scanRowsIntoInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, *inv)
+	}
+
+	return invitations, rows.Err()
+}
+
+func (s *memberStore) GetInvitationByTokenHash(tokenHash string) (*types.CompanyInvitation, error) {
+	rows, err := s.db.Query(selectInvitation+" WHERE tokenHash = ?", tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	inv := new(types.CompanyInvitation)
+	for rows.Next() {
+		inv, err = scanRowsIntoInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if inv.ID == 0 {
+		return nil, ErrInvitationUnavailable
+	}
+
+	return inv, nil
+}
+
+// AcceptInvitation consumes the invitation and creates the invited user
+// with their seat in one transaction, returning the new user's ID. It fails
+// with ErrInvitationUnavailable, leaving nothing behind, if the invitation
+// was accepted or expired in the meantime.
+func (s *memberStore) AcceptInvitation(inv *types.CompanyInvitation, passwordHash string) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		`UPDATE CompanyInvitation SET acceptedAt = UTC_TIMESTAMP()
+		WHERE id = ? AND acceptedAt IS NULL AND expiresAt > UTC_TIMESTAMP()`,
+		inv.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return 0, ErrInvitationUnavailable
+	}
+
+	now := time.Now().UTC()
+	res, err = tx.Exec(
+		"INSERT INTO User (email, password, role, isActive, createdAt, updatedAt) VALUES (?, ?, 'Company', TRUE, ?, ?)",
+		inv.Email,
+		passwordHash,
+		now,
+		now,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	userID, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO CompanyMember (companyID, userID, role) VALUES (?, ?, ?)",
+		inv.CompanyID,
+		userID,
+		inv.Role,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(userID), nil
+}
+
+func (s *memberStore) RevokeInvitation(companyID, invitationID int) error {
+	res, err := s.db.Exec(
+		"DELETE FROM CompanyInvitation WHERE id = ? AND companyID = ? AND acceptedAt IS NULL",
+		invitationID,
+		companyID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("invitation not found")
+	}
+
+	return nil
+}
+
+const selectMember = `SELECT m.companyID, m.userID, u.email, m.role, m.createdAt
+	FROM CompanyMember m
+	JOIN User u ON u.id = m.userID`
+
+func scanRowsIntoMember(rows *sql.Rows) (*types.CompanyMember, error) {
+	m := new(types.CompanyMember)
+
+	err := rows.Scan(
+		&m.CompanyID,
+		&m.UserID,
+		&m.Email,
+		&m.Role,
+		&m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+const selectInvitation = `SELECT id, companyID, email, role, COALESCE(invitedBy, 0), expiresAt, acceptedAt, createdAt
+	FROM CompanyInvitation`
+
+func scanRowsIntoInvitation(rows *sql.Rows) (*types.CompanyInvitation, error) {
+	inv := new(types.CompanyInvitation)
+
+	var acceptedAt sql.NullTime
+	err := rows.Scan(
+		&inv.ID,
+		&inv.CompanyID,
+		&inv.Email,
+		&inv.Role,
+		&inv.InvitedBy,
+		&inv.ExpiresAt,
+		&acceptedAt,
+		&inv.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if acceptedAt.Valid {
+		inv.AcceptedAt = &acceptedAt.Time
+	}
+
+	return inv, nil
+}
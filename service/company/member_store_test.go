@@ -0,0 +1,118 @@
+package company_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/testutils"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+func TestRoleRanks(t *testing.T) {
+	if !company.HasRole(&types.CompanyMember{Role: company.RoleOwner}, company.RoleAdmin) {
+		t.Error("expected owner to have admin rights")
+	}
+	if company.HasRole(&types.CompanyMember{Role: company.RoleRecruiter}, company.RoleAdmin) {
+		t.Error("expected recruiter not to have admin rights")
+	}
+	if company.HasRole(nil, company.RoleViewer) {
+		t.Error("expected non-members to have no rights")
+	}
+	if company.Outranks(company.RoleAdmin, company.RoleAdmin) {
+		t.Error("expected admins not to outrank each other")
+	}
+	if !company.Outranks(company.RoleAdmin, company.RoleViewer) {
+		t.Error("expected admin to outrank viewer")
+	}
+}
+
+func TestMemberStore_InvitationAndOwnership(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userStore := user.NewUserStore(db)
+
+	ownerID, err := userStore.CreateUser(&types.User{
+		Email:    "owner@example.com",
+		Password: "validpass123",
+		Role:     "Company",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+
+	cpy := &types.Company{Name: "Acme", UserID: ownerID}
+	if err := company.NewCompany(db).CreateCompany(cpy); err != nil {
+		t.Fatal("CreateCompany failed:", err)
+	}
+
+	store := company.NewMemberStore(db)
+
+	owner, err := store.GetMember(cpy.ID, ownerID)
+	if err != nil {
+		t.Fatal("GetMember failed:", err)
+	}
+	if owner.Role != company.RoleOwner {
+		t.Fatalf("expected the creator to be owner, got %q", owner.Role)
+	}
+
+	inv := &types.CompanyInvitation{
+		CompanyID: cpy.ID,
+		Email:     "recruiter@example.com",
+		Role:      company.RoleRecruiter,
+		InvitedBy: ownerID,
+		ExpiresAt: time.Now().UTC().Add(time.Hour).Truncate(time.Second),
+	}
+	if err := store.CreateInvitation(inv, "hash"); err != nil {
+		t.Fatal("CreateInvitation failed:", err)
+	}
+
+	found, err := store.GetInvitationByTokenHash("hash")
+	if err != nil {
+		t.Fatal("GetInvitationByTokenHash failed:", err)
+	}
+
+	recruiterID, err := store.AcceptInvitation(found, "validpass123")
+	if err != nil {
+		t.Fatal("AcceptInvitation failed:", err)
+	}
+	if _, err := store.AcceptInvitation(found, "validpass123"); !errors.Is(err, company.ErrInvitationUnavailable) {
+		t.Errorf("expected ErrInvitationUnavailable accepting twice, got %v", err)
+	}
+
+	u, err := userStore.GetUserByEmail(inv.Email)
+	if err != nil || u.ID != recruiterID || u.Role != "Company" {
+		t.Fatalf("expected the invited user to be created, got %+v, %v", u, err)
+	}
+
+	m, err := store.GetMembershipByUserID(recruiterID)
+	if err != nil || m == nil || m.CompanyID != cpy.ID {
+		t.Fatalf("expected the recruiter to belong to the company, got %+v, %v", m, err)
+	}
+
+	if err := store.TransferOwnership(cpy.ID, ownerID, recruiterID); err != nil {
+		t.Fatal("TransferOwnership failed:", err)
+	}
+
+	members, err := store.GetMembers(cpy.ID)
+	if err != nil {
+		t.Fatal("GetMembers failed:", err)
+	}
+	if len(members) != 2 || members[0].UserID != recruiterID || members[1].Role != company.RoleAdmin {
+		t.Errorf("expected the recruiter as owner and the previous owner as admin, got %+v", members)
+	}
+
+	if err := store.RemoveMember(cpy.ID, ownerID); err != nil {
+		t.Fatal("RemoveMember failed:", err)
+	}
+	m, err = store.GetMembershipByUserID(ownerID)
+	if err != nil {
+		t.Fatal("GetMembershipByUserID failed:", err)
+	}
+	if m != nil {
+		t.Errorf("expected the removed member to have no seat, got %+v", m)
+	}
+}
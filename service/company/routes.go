@@ -0,0 +1,195 @@
+package company
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/mailer"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+type Handler struct {
+	CompanyRepo types.CompanyRepository
+	MemberRepo  types.CompanyMemberRepository
+	UserRepo    types.UserRepository
+	Mailer      mailer.Mailer
+}
+
+// NewHandler takes the user store from the caller because the user package
+// already depends on this one.
+func NewHandler(db *sql.DB, userRepo types.UserRepository, m mailer.Mailer) *Handler {
+	return &Handler{
+		CompanyRepo: NewCompany(db),
+		MemberRepo:  NewMemberStore(db),
+		UserRepo:    userRepo,
+		Mailer:      m,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/companies", h.handleGetCompanies).Methods("GET")
+	router.HandleFunc("/companies/{companyID:[0-9]+}", h.handleGetCompany).Methods("GET")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}",
+		auth.WithJWTAuth(h.handleUpdateCompany, h.UserRepo),
+	).Methods("PATCH")
+
+	h.registerMemberRoutes(router)
+}
+
+// @Summary List companies
+// @Description List public company profiles by name, optionally filtered by industry and size.
+// @Tags companies
+// @Produce json
+// @Param industry query string false "Industry"
+// @Param size query string false "Company size"
+// @Param page query int false "Page number (default 1)"
+// @Param pageSize query int false "Page size (default 20, max 100)"
+// @Success 200 {array} types.CompanyProfile "Companies"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies [get]
+func (h *Handler) handleGetCompanies(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := utils.ParsePagination(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	q := r.URL.Query()
+	profiles, err := h.CompanyRepo.GetCompanyProfiles(q.Get("industry"), q.Get("size"), limit, offset)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, profiles)
+}
+
+// @Summary Get a company
+// @Description Public profile of a company with its review aggregates.
+// @Tags companies
+// @Produce json
+// @Param companyID path int true "Company ID"
+// @Success 200 {object} types.CompanyProfile "Company"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Router /api/v1/companies/{companyID} [get]
+func (h *Handler) handleGetCompany(w http.ResponseWriter, r *http.Request) {
+	companyID, err := strconv.Atoi(mux.Vars(r)["companyID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid company id"))
+		return
+	}
+
+	profile, err := h.CompanyRepo.GetCompanyProfileByID(companyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, profile)
+}
+
+// @Summary Update a company
+// @Description Edit a company profile. Requires the owner or admin role on the company team. Omitted fields are left unchanged, and the result must pass the same checks as registration.
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Param company body types.UpdateCompanyRequest true "Fields to change"
+// @Success 200 {object} types.CompanyProfile "Updated company"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID} [patch]
+func (h *Handler) handleUpdateCompany(w http.ResponseWriter, r *http.Request) {
+	companyID, err := strconv.Atoi(mux.Vars(r)["companyID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid company id"))
+		return
+	}
+
+	cpy, err := h.CompanyRepo.GetCompanyByID(companyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+
+	m, err := h.MemberRepo.GetMember(cpy.ID, auth.GetUserIDFromContext(r.Context()))
+	if err != nil || !HasRole(m, RoleAdmin) {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("permission denied"))
+		return
+	}
+
+	var req types.UpdateCompanyRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	applyCompanyUpdate(cpy, &req)
+
+	// Hold the result to the same rules as registration and PATCH /me, so
+	// the company stays editable through both routes.
+	updated := types.UpdateProfileRequest{Role: "Company", CompanyRequest: RequestFromCompany(cpy)}
+	if err := utils.Validate.Struct(updated); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	if err := h.CompanyRepo.UpdateCompany(cpy); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	profile, err := h.CompanyRepo.GetCompanyProfileByID(cpy.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, profile)
+}
+
+// RequestFromCompany returns the company's editable fields in the shape used
+// for registration and profile validation.
+func RequestFromCompany(cpy *types.Company) types.CompanyRequest {
+	return types.CompanyRequest{
+		Name:         cpy.Name,
+		Headquarters: cpy.Headquarters,
+		Website:      cpy.Website,
+		Industry:     cpy.Industry,
+		CompanySize:  cpy.CompanySize,
+	}
+}
+
+func applyCompanyUpdate(cpy *types.Company, req *types.UpdateCompanyRequest) {
+	if req.Name != nil {
+		cpy.Name = *req.Name
+	}
+	if req.Headquarters != nil {
+		cpy.Headquarters = *req.Headquarters
+	}
+	if req.Website != nil {
+		cpy.Website = *req.Website
+	}
+	if req.Industry != nil {
+		cpy.Industry = *req.Industry
+	}
+	if req.CompanySize != nil {
+		cpy.CompanySize = *req.CompanySize
+	}
+}
@@ -2,6 +2,7 @@ package company
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/AyKrimino/JobSeekerAPI/types"
 )
@@ -16,8 +17,15 @@ func NewCompany(db *sql.DB) types.CompanyRepository {
 	}
 }
 
+// CreateCompany stores the company and seats its creator as the owner.
 func (s *companyStore) CreateCompany(cpy *types.Company) error {
-	_, err := s.db.Exec(
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
 		"INSERT INTO Company (name, headquarters, website, industry, companySize, userID) VALUES (?, ?, ?, ?, ?, ?)",
 		cpy.Name,
 		cpy.Headquarters,
@@ -26,6 +34,185 @@ func (s *companyStore) CreateCompany(cpy *types.Company) error {
 		cpy.CompanySize,
 		cpy.UserID,
 	)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO CompanyMember (companyID, userID, role) VALUES (?, ?, ?)",
+		id,
+		cpy.UserID,
+		RoleOwner,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	cpy.ID = int(id)
+
+	return nil
+}
+
+func (s *companyStore) GetCompanyByID(id int) (*types.Company, error) {
+	rows, err := s.db.Query(
+		"SELECT id, name, COALESCE(headquarters, ''), COALESCE(website, ''), COALESCE(industry, ''), COALESCE(companySize, ''), COALESCE(userId, 0) FROM Company WHERE id = ?",
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cpy := new(types.Company)
+	for rows.Next() {
+		cpy, err = scanRowsIntoCompany(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cpy.ID == 0 {
+		return nil, fmt.Errorf("company not found")
+	}
+
+	return cpy, nil
+}
+
+func (s *companyStore) GetCompanyProfileByID(id int) (*types.CompanyProfile, error) {
+	rows, err := s.db.Query(selectCompanyProfile+" WHERE c.id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	p := new(types.CompanyProfile)
+	for rows.Next() {
+		p, err = scanRowsIntoCompanyProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.ID == 0 {
+		return nil, fmt.Errorf("company not found")
+	}
+
+	return p, nil
+}
+
+// GetCompanyProfiles lists companies by name. Empty industry or size
+// filters match every company.
+func (s *companyStore) GetCompanyProfiles(
+	industry, size string,
+	limit, offset int,
+) ([]types.CompanyProfile, error) {
+	rows, err := s.db.Query(
+		selectCompanyProfile+`
+		WHERE (? = '' OR c.industry = ?) AND (? = '' OR c.companySize = ?)
+		ORDER BY c.name, c.id
+		LIMIT ? OFFSET ?`,
+		industry,
+		industry,
+		size,
+		size,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := make([]types.CompanyProfile, 0)
+	for rows.Next() {
+		p, err := scanRowsIntoCompanyProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *p)
+	}
+
+	return profiles, rows.Err()
+}
+
+func (s *companyStore) UpdateCompany(cpy *types.Company) error {
+	res, err := s.db.Exec(
+		"UPDATE Company SET name = ?, headquarters = ?, website = ?, industry = ?, companySize = ? WHERE id = ?",
+		cpy.Name,
+		cpy.Headquarters,
+		cpy.Website,
+		cpy.Industry,
+		cpy.CompanySize,
+		cpy.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		if _, err := s.GetCompanyByID(cpy.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const selectCompanyProfile = `SELECT c.id, c.name, COALESCE(c.headquarters, ''), COALESCE(c.website, ''),
+	COALESCE(c.industry, ''), COALESCE(c.companySize, ''),
+	COALESCE(ROUND(r.averageRating, 2), 0), COALESCE(r.reviewCount, 0)
+	FROM Company c
+	LEFT JOIN (
+		SELECT companyID, AVG(overallRating) AS averageRating, COUNT(*) AS reviewCount
+		FROM CompanyReview
+		WHERE status = 'Approved'
+		GROUP BY companyID
+	) r ON r.companyID = c.id`
+
+func scanRowsIntoCompany(rows *sql.Rows) (*types.Company, error) {
+	cpy := new(types.Company)
+
+	err := rows.Scan(
+		&cpy.ID,
+		&cpy.Name,
+		&cpy.Headquarters,
+		&cpy.Website,
+		&cpy.Industry,
+		&cpy.CompanySize,
+		&cpy.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return cpy, nil
+}
+
+func scanRowsIntoCompanyProfile(rows *sql.Rows) (*types.CompanyProfile, error) {
+	p := new(types.CompanyProfile)
+
+	err := rows.Scan(
+		&p.ID,
+		&p.Name,
+		&p.Headquarters,
+		&p.Website,
+		&p.Industry,
+		&p.CompanySize,
+		&p.AverageRating,
+		&p.ReviewCount,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	return p, nil
 }
@@ -67,3 +67,58 @@ func TestCreateCompany_NonExistantUser(t *testing.T) {
 		t.Error("expected a non-nil error but got a nil error")
 	}
 }
+
+func TestGetCompanyProfiles_FiltersAndUpdate(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userStore := user.NewUserStore(db)
+	cpyStore := company.NewCompany(db)
+
+	for i, industry := range []string{"Software", "Retail"} {
+		userID, err := userStore.CreateUser(&types.User{
+			Email:    []string{"soft@example.com", "retail@example.com"}[i],
+			Password: "validpass123",
+			Role:     "Company",
+		})
+		if err != nil {
+			t.Fatal("CreateUser failed:", err)
+		}
+
+		err = cpyStore.CreateCompany(&types.Company{
+			Name:        industry + "Co",
+			Industry:    industry,
+			CompanySize: "Small",
+			UserID:      userID,
+		})
+		if err != nil {
+			t.Fatal("CreateCompany failed:", err)
+		}
+	}
+
+	profiles, err := cpyStore.GetCompanyProfiles("Software", "", 10, 0)
+	if err != nil {
+		t.Fatal("GetCompanyProfiles failed:", err)
+	}
+	if len(profiles) != 1 || profiles[0].Name != "SoftwareCo" {
+		t.Fatalf("expected only SoftwareCo, got %+v", profiles)
+	}
+
+	cpy, err := cpyStore.GetCompanyByID(profiles[0].ID)
+	if err != nil {
+		t.Fatal("GetCompanyByID failed:", err)
+	}
+
+	cpy.CompanySize = "Large"
+	if err := cpyStore.UpdateCompany(cpy); err != nil {
+		t.Fatal("UpdateCompany failed:", err)
+	}
+
+	profile, err := cpyStore.GetCompanyProfileByID(cpy.ID)
+	if err != nil {
+		t.Fatal("GetCompanyProfileByID failed:", err)
+	}
+	if profile.CompanySize != "Large" || profile.ReviewCount != 0 {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+}
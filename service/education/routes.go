@@ -0,0 +1,230 @@
+package education
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+type Handler struct {
+	EducationRepo types.EducationRepository
+	JobSeekerRepo types.JobSeekerRepository
+	UserRepo      types.UserRepository
+}
+
+func NewHandler(db *sql.DB) *Handler {
+	return &Handler{
+		EducationRepo: NewEducationStore(db),
+		JobSeekerRepo: jobseeker.NewJobseekerStore(db),
+		UserRepo:      user.NewUserStore(db),
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/me/education", auth.WithJWTAuth(h.handleGetEducation, h.UserRepo)).Methods("GET")
+	router.HandleFunc("/me/education", auth.WithJWTAuth(h.handleCreateEducation, h.UserRepo)).Methods("POST")
+	router.HandleFunc(
+		"/me/education/{educationID:[0-9]+}",
+		auth.WithJWTAuth(h.handleUpdateEducation, h.UserRepo),
+	).Methods("PUT")
+	router.HandleFunc(
+		"/me/education/{educationID:[0-9]+}",
+		auth.WithJWTAuth(h.handleDeleteEducation, h.UserRepo),
+	).Methods("DELETE")
+}
+
+// @Summary List education
+// @Description List the authenticated job seeker's education, most recent first.
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.Education "Education"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/education [get]
+func (h *Handler) handleGetEducation(w http.ResponseWriter, r *http.Request) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	records, err := h.EducationRepo.GetEducationByJobSeekerID(js.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, records)
+}
+
+// @Summary Add education
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param education body types.EducationRequest true "Education"
+// @Success 201 {object} types.Education "Education created"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/education [post]
+func (h *Handler) handleCreateEducation(w http.ResponseWriter, r *http.Request) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	req, ok := parseEducationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	e := &types.Education{JobSeekerID: js.ID}
+	applyEducationRequest(e, req)
+
+	if err := h.EducationRepo.CreateEducation(e); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, e)
+}
+
+// @Summary Update education
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param educationID path int true "Education ID"
+// @Param education body types.EducationRequest true "Education"
+// @Success 200 {object} types.Education "Education updated"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/education/{educationID} [put]
+func (h *Handler) handleUpdateEducation(w http.ResponseWriter, r *http.Request) {
+	e, ok := h.educationFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	req, ok := parseEducationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	applyEducationRequest(e, req)
+
+	if err := h.EducationRepo.UpdateEducation(e); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, e)
+}
+
+// @Summary Delete education
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Param educationID path int true "Education ID"
+// @Success 200 {object} types.SuccessResponse "Education deleted"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/education/{educationID} [delete]
+func (h *Handler) handleDeleteEducation(w http.ResponseWriter, r *http.Request) {
+	e, ok := h.educationFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.EducationRepo.DeleteEducation(e); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Education deleted"})
+}
+
+func (h *Handler) jobSeekerFromContext(w http.ResponseWriter, r *http.Request) (*types.JobSeeker, bool) {
+	if !strings.EqualFold(auth.GetUserRoleFromContext(r.Context()), "JobSeeker") {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("only job seekers have education records"))
+		return nil, false
+	}
+
+	js, err := h.JobSeekerRepo.GetJobSeekerByUserID(auth.GetUserIDFromContext(r.Context()))
+	if err != nil {
+		utils.WriteError(w, http.StatusForbidden, err)
+		return nil, false
+	}
+
+	return js, true
+}
+
+// educationFromPath loads the record in the URL, answering 404 when it
+// belongs to someone else.
+func (h *Handler) educationFromPath(w http.ResponseWriter, r *http.Request) (*types.Education, bool) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	educationID, err := strconv.Atoi(mux.Vars(r)["educationID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid education id"))
+		return nil, false
+	}
+
+	e, err := h.EducationRepo.GetEducationByID(educationID)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+	if e.JobSeekerID != js.ID {
+		utils.WriteError(w, http.StatusNotFound, fmt.Errorf("education not found"))
+		return nil, false
+	}
+
+	return e, true
+}
+
+func parseEducationRequest(w http.ResponseWriter, r *http.Request) (*types.EducationRequest, bool) {
+	var req types.EducationRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return nil, false
+	}
+
+	// YYYY-MM-DD dates order the same as strings.
+	if req.StartDate != nil && req.EndDate != nil && *req.EndDate < *req.StartDate {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("endDate must not be before startDate"))
+		return nil, false
+	}
+
+	return &req, true
+}
+
+func applyEducationRequest(e *types.Education, req *types.EducationRequest) {
+	e.Institution = req.Institution
+	e.Degree = req.Degree
+	e.FieldOfStudy = req.FieldOfStudy
+	e.StartDate = req.StartDate
+	e.EndDate = req.EndDate
+}
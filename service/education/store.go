@@ -0,0 +1,203 @@
+package education
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/skill"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+type educationStore struct {
+	db *sql.DB
+}
+
+func NewEducationStore(db *sql.DB) types.EducationRepository {
+	return &educationStore{
+		db: db,
+	}
+}
+
+// GetEducationByJobSeekerID lists a job seeker's education, most recent
+// first. Records without dates are listed last.
+func (s *educationStore) GetEducationByJobSeekerID(jobSeekerID int) ([]types.Education, error) {
+	rows, err := s.db.Query(
+		selectEducation+" WHERE jobSeekerID = ? ORDER BY "+latestFirst,
+		jobSeekerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]types.Education, 0)
+	for rows.Next() {
+		e, err := scanRowsIntoEducation(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *e)
+	}
+
+	return records, rows.Err()
+}
+
+func (s *educationStore) GetEducationByID(id int) (*types.Education, error) {
+	rows, err := s.db.Query(selectEducation+" WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	e := new(types.Education)
+	for rows.Next() {
+		e, err = scanRowsIntoEducation(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if e.ID == 0 {
+		return nil, fmt.Errorf("education not found")
+	}
+
+	return e, nil
+}
+
+// CreateEducation stores the record and refreshes the education summary on
+// the job seeker's profile.
+func (s *educationStore) CreateEducation(e *types.Education) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		"INSERT INTO Education (jobSeekerID, institution, degree, fieldOfStudy, startDate, endDate) VALUES (?, ?, ?, ?, ?, ?)",
+		e.JobSeekerID,
+		e.Institution,
+		e.Degree,
+		e.FieldOfStudy,
+		e.StartDate,
+		e.EndDate,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if err := RecomputeEducationSummary(tx, e.JobSeekerID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	e.ID = int(id)
+
+	return nil
+}
+
+func (s *educationStore) UpdateEducation(e *types.Education) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		"UPDATE Education SET institution = ?, degree = ?, fieldOfStudy = ?, startDate = ?, endDate = ? WHERE id = ?",
+		e.Institution,
+		e.Degree,
+		e.FieldOfStudy,
+		e.StartDate,
+		e.EndDate,
+		e.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := RecomputeEducationSummary(tx, e.JobSeekerID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *educationStore) DeleteEducation(e *types.Education) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM Education WHERE id = ?", e.ID); err != nil {
+		return err
+	}
+
+	if err := RecomputeEducationSummary(tx, e.JobSeekerID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RecomputeEducationSummary stores the job seeker's latest education record
+// as "degree, field of study, institution" in JobSeeker.education, where
+// profile reads expect it. The column is never edited directly.
+func RecomputeEducationSummary(q skill.Querier, jobSeekerID int) error {
+	_, err := q.Exec(
+		`UPDATE JobSeeker js SET education = (
+			SELECT LEFT(CONCAT_WS(', ', NULLIF(e.degree, ''), NULLIF(e.fieldOfStudy, ''), NULLIF(e.institution, '')), 255)
+			FROM Education e
+			WHERE e.jobSeekerID = js.id
+			ORDER BY `+latestFirst+`
+			LIMIT 1
+		)
+		WHERE js.id = ?`,
+		jobSeekerID,
+	)
+
+	return err
+}
+
+// latestFirst orders education records by their latest date with undated
+// records last, so an undated record never outranks a dated degree.
+const latestFirst = "COALESCE(endDate, startDate) IS NULL, COALESCE(endDate, startDate) DESC, id DESC"
+
+const selectEducation = `SELECT id, jobSeekerID, institution, COALESCE(degree, ''), COALESCE(fieldOfStudy, ''),
+	DATE_FORMAT(startDate, '%Y-%m-%d'), DATE_FORMAT(endDate, '%Y-%m-%d')
+	FROM Education`
+
+func scanRowsIntoEducation(rows *sql.Rows) (*types.Education, error) {
+	e := new(types.Education)
+
+	var startDate, endDate sql.NullString
+	err := rows.Scan(
+		&e.ID,
+		&e.JobSeekerID,
+		&e.Institution,
+		&e.Degree,
+		&e.FieldOfStudy,
+		&startDate,
+		&endDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if startDate.Valid {
+		e.StartDate = &startDate.String
+	}
+	if endDate.Valid {
+		e.EndDate = &endDate.String
+	}
+
+	return e, nil
+}
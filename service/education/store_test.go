@@ -0,0 +1,107 @@
+package education_test
+
+import (
+	"testing"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/education"
+	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/testutils"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+func TestEducationStore_DerivesSummary(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userID, err := user.NewUserStore(db).CreateUser(&types.User{
+		Email:    "edu@test.com",
+		Password: "Pass1234",
+		Role:     "JobSeeker",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+
+	js := &types.JobSeeker{
+		FirstName: "fname",
+		LastName:  "lname",
+		Education: "BSc Computer Science",
+		UserID:    userID,
+	}
+	if err := jobseeker.NewJobseekerStore(db).CreateJobSeeker(js); err != nil {
+		t.Fatal("CreateJobSeeker failed:", err)
+	}
+
+	store := education.NewEducationStore(db)
+
+	records, err := store.GetEducationByJobSeekerID(js.ID)
+	if err != nil {
+		t.Fatal("GetEducationByJobSeekerID failed:", err)
+	}
+	if len(records) != 1 || records[0].Degree != "BSc Computer Science" {
+		t.Fatalf("expected the registration education as a record, got %+v", records)
+	}
+
+	e := &records[0]
+	e.Institution = "MIT"
+	if err := store.UpdateEducation(e); err != nil {
+		t.Fatal("UpdateEducation failed:", err)
+	}
+
+	got, err := store.GetEducationByID(e.ID)
+	if err != nil {
+		t.Fatal("GetEducationByID failed:", err)
+	}
+	if got.Institution != "MIT" {
+		t.Errorf("expected institution MIT, got %q", got.Institution)
+	}
+
+	jobSeekerStore := jobseeker.NewJobseekerStore(db)
+	profile, err := jobSeekerStore.GetJobSeekerByUserID(userID)
+	if err != nil {
+		t.Fatal("GetJobSeekerByUserID failed:", err)
+	}
+	if profile.Education != "BSc Computer Science, MIT" {
+		t.Errorf("expected the education summary to follow the record, got %q", profile.Education)
+	}
+
+	endDate := "2020-06-01"
+	msc := &types.Education{
+		JobSeekerID:  js.ID,
+		Institution:  "ETH",
+		Degree:       "MSc",
+		FieldOfStudy: "Robotics",
+		EndDate:      &endDate,
+	}
+	if err := store.CreateEducation(msc); err != nil {
+		t.Fatal("CreateEducation failed:", err)
+	}
+
+	profile, err = jobSeekerStore.GetJobSeekerByUserID(userID)
+	if err != nil {
+		t.Fatal("GetJobSeekerByUserID failed:", err)
+	}
+	if profile.Education != "MSc, Robotics, ETH" {
+		t.Errorf("expected the dated record to outrank the undated one, got %q", profile.Education)
+	}
+
+	if err := store.DeleteEducation(msc); err != nil {
+		t.Fatal("DeleteEducation failed:", err)
+	}
+
+	if err := store.DeleteEducation(e); err != nil {
+		t.Fatal("DeleteEducation failed:", err)
+	}
+	if _, err := store.GetEducationByID(e.ID); err == nil {
+		t.Error("expected the record to be deleted")
+	}
+
+	profile, err = jobSeekerStore.GetJobSeekerByUserID(userID)
+	if err != nil {
+		t.Fatal("GetJobSeekerByUserID failed:", err)
+	}
+	if profile.Education != "" {
+		t.Errorf("expected an empty education summary, got %q", profile.Education)
+	}
+}
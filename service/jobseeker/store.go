@@ -3,7 +3,9 @@ package jobseeker
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
+	"github.com/AyKrimino/JobSeekerAPI/service/skill"
 	"github.com/AyKrimino/JobSeekerAPI/types"
 	"github.com/AyKrimino/JobSeekerAPI/utils"
 )
@@ -18,22 +20,208 @@ func NewJobseekerStore(db *sql.DB) types.JobSeekerRepository {
 	}
 }
 
+// CreateJobSeeker stores the profile and links its skills to the skills
+// catalogue. The JSON skills column keeps the canonical names for clients
+// that still read it.
 func (s *jobseekerStore) CreateJobSeeker(js *types.JobSeeker) error {
-	skillsJSON, err := utils.EncodeStringSliceToJSON(js.Skills)
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	skills, err := skill.ResolveSkills(tx, js.Skills)
+	if err != nil {
+		return fmt.Errorf("error resolving skills: %v", err)
+	}
+
+	var skillNames []string
+	if len(skills) > 0 {
+		skillNames = skill.Names(skills)
+	}
+
+	skillsJSON, err := utils.EncodeStringSliceToJSON(skillNames)
 	if err != nil {
 		return fmt.Errorf("error encoding skills to JSON: %v", err)
 	}
 
-	_, err = s.db.Exec(
-		"INSERT INTO JobSeeker (firstName, lastName, profileSummary, skills, experience, education, userID) VALUES (?, ?, ?, ?, ?, ?, ?)",
+	res, err := tx.Exec(
+		"INSERT INTO JobSeeker (firstName, lastName, profileSummary, skills, experience, statedExperience, education, location, userID) VALUES (?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?)",
 		js.FirstName,
 		js.LastName,
 		js.ProfileSummary,
 		skillsJSON,
 		js.Experience,
+		js.Experience,
 		js.Education,
+		js.Location,
 		js.UserID,
 	)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, sk := range skills {
+		_, err := tx.Exec(
+			"INSERT INTO JobSeekerSkill (jobSeekerID, skillID) VALUES (?, ?)",
+			id,
+			sk.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := createInitialEducation(tx, int(id), js); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	js.ID = int(id)
+	js.Skills = skillNames
+
+	return nil
+}
+
+// createInitialEducation turns the education given at registration into an
+// Education record, so the education summary stays derived from records.
+// Experience is kept as stated until the job seeker adds positions.
+func createInitialEducation(tx *sql.Tx, jobSeekerID int, js *types.JobSeeker) error {
+	education := strings.TrimSpace(js.Education)
+	if education == "" {
+		return nil
+	}
+
+	_, err := tx.Exec(
+		"INSERT INTO Education (jobSeekerID, institution, degree) VALUES (?, '', ?)",
+		jobSeekerID,
+		education,
+	)
 
 	return err
 }
+
+func (s *jobseekerStore) GetJobSeekerByUserID(userID int) (*types.JobSeeker, error) {
+	rows, err := s.db.Query(
+		"SELECT id, firstName, lastName, COALESCE(profileSummary, ''), skills, COALESCE(experience, 0), COALESCE(education, ''), COALESCE(location, ''), userID FROM JobSeeker WHERE userID = ?",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	js := new(types.JobSeeker)
+	for rows.Next() {
+		js, err = scanRowsIntoJobSeeker(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if js.ID == 0 {
+		return nil, fmt.Errorf("job seeker not found")
+	}
+
+	return js, nil
+}
+
+// UpdateJobSeeker saves the profile and replaces its skill links, resolving
+// the names against the skills catalogue as CreateJobSeeker does.
+func (s *jobseekerStore) UpdateJobSeeker(js *types.JobSeeker) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	skills, err := skill.ResolveSkills(tx, js.Skills)
+	if err != nil {
+		return fmt.Errorf("error resolving skills: %v", err)
+	}
+
+	var skillNames []string
+	if len(skills) > 0 {
+		skillNames = skill.Names(skills)
+	}
+
+	skillsJSON, err := utils.EncodeStringSliceToJSON(skillNames)
+	if err != nil {
+		return fmt.Errorf("error encoding skills to JSON: %v", err)
+	}
+
+	_, err = tx.Exec(
+		"UPDATE JobSeeker SET firstName = ?, lastName = ?, profileSummary = ?, skills = ?, experience = ?, education = ?, location = NULLIF(?, '') WHERE id = ?",
+		js.FirstName,
+		js.LastName,
+		js.ProfileSummary,
+		skillsJSON,
+		js.Experience,
+		js.Education,
+		js.Location,
+		js.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM JobSeekerSkill WHERE jobSeekerID = ?", js.ID); err != nil {
+		return err
+	}
+
+	for _, sk := range skills {
+		_, err := tx.Exec(
+			"INSERT INTO JobSeekerSkill (jobSeekerID, skillID) VALUES (?, ?)",
+			js.ID,
+			sk.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	js.Skills = skillNames
+
+	return nil
+}
+
+func scanRowsIntoJobSeeker(rows *sql.Rows) (*types.JobSeeker, error) {
+	js := new(types.JobSeeker)
+
+	var skillsJSON []byte
+	err := rows.Scan(
+		&js.ID,
+		&js.FirstName,
+		&js.LastName,
+		&js.ProfileSummary,
+		&skillsJSON,
+		&js.Experience,
+		&js.Education,
+		&js.Location,
+		&js.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(skillsJSON) > 0 {
+		js.Skills, err = utils.DecodeJSONTOStringSlice(skillsJSON)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return js, nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
+	"github.com/AyKrimino/JobSeekerAPI/service/skill"
 	"github.com/AyKrimino/JobSeekerAPI/service/user"
 	"github.com/AyKrimino/JobSeekerAPI/testutils"
 	"github.com/AyKrimino/JobSeekerAPI/types"
@@ -63,3 +64,98 @@ func TestCreateJobSeeker_NonExistantUser(t *testing.T) {
 		t.Error("expected a nil error")
 	}
 }
+
+func TestCreateJobSeeker_NormalizesSkills(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userStore := user.NewUserStore(db)
+
+	userID, err := userStore.CreateUser(&types.User{
+		Email:    "skills@test.com",
+		Password: "Pass1234",
+		Role:     "JobSeeker",
+	})
+	if err != nil {
+		t.Fatal("expected a nil error but got a non-nil error: ", err)
+	}
+
+	jsStore := jobseeker.NewJobseekerStore(db)
+
+	js := &types.JobSeeker{
+		FirstName: "fname",
+		LastName:  "lname",
+		Skills:    []string{"JS", "javascript", "JavaScript ", "Go"},
+		UserID:    userID,
+	}
+
+	err = jsStore.CreateJobSeeker(js)
+	if err != nil {
+		t.Fatal("expected nil error but got a non-nil error: ", err)
+	}
+
+	skills, err := skill.NewSkillStore(db).GetSkillsByJobSeekerID(js.ID)
+	if err != nil {
+		t.Fatal("GetSkillsByJobSeekerID failed:", err)
+	}
+
+	if len(skills) != 2 {
+		t.Fatalf("expected 2 skills, got %v", skills)
+	}
+	if skills[0].Name != "Go" || skills[1].Name != "JavaScript" {
+		t.Errorf("expected [Go JavaScript], got %v", skills)
+	}
+}
+
+func TestUpdateJobSeeker_ReplacesSkills(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userID, err := user.NewUserStore(db).CreateUser(&types.User{
+		Email:    "update@test.com",
+		Password: "Pass1234",
+		Role:     "JobSeeker",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+
+	jsStore := jobseeker.NewJobseekerStore(db)
+
+	err = jsStore.CreateJobSeeker(&types.JobSeeker{
+		FirstName: "fname",
+		LastName:  "lname",
+		Skills:    []string{"Go"},
+		UserID:    userID,
+	})
+	if err != nil {
+		t.Fatal("CreateJobSeeker failed:", err)
+	}
+
+	js, err := jsStore.GetJobSeekerByUserID(userID)
+	if err != nil {
+		t.Fatal("GetJobSeekerByUserID failed:", err)
+	}
+
+	js.ProfileSummary = "summary"
+	js.Skills = []string{"javascript"}
+	if err := jsStore.UpdateJobSeeker(js); err != nil {
+		t.Fatal("UpdateJobSeeker failed:", err)
+	}
+
+	skills, err := skill.NewSkillStore(db).GetSkillsByJobSeekerID(js.ID)
+	if err != nil {
+		t.Fatal("GetSkillsByJobSeekerID failed:", err)
+	}
+	if len(skills) != 1 || skills[0].Name != "JavaScript" {
+		t.Errorf("expected [JavaScript], got %v", skills)
+	}
+
+	got, err := jsStore.GetJobSeekerByUserID(userID)
+	if err != nil {
+		t.Fatal("GetJobSeekerByUserID failed:", err)
+	}
+	if got.ProfileSummary != "summary" || len(got.Skills) != 1 || got.Skills[0] != "JavaScript" {
+		t.Errorf("unexpected job seeker %+v", got)
+	}
+}
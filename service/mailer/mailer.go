@@ -0,0 +1,68 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/AyKrimino/JobSeekerAPI/config"
+)
+
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// NewMailer returns an SMTP mailer when SMTP_HOST is configured and a mailer
+// that only logs messages otherwise, which is what local development and
+// tests use.
+func NewMailer() Mailer {
+	if config.Envs.SMTPHost == "" {
+		return &LogMailer{}
+	}
+
+	return &SMTPMailer{
+		Addr: net.JoinHostPort(config.Envs.SMTPHost, config.Envs.SMTPPort),
+		Host: config.Envs.SMTPHost,
+		User: config.Envs.SMTPUser,
+		Pass: config.Envs.SMTPPassword,
+		From: config.Envs.MailFrom,
+	}
+}
+
+type LogMailer struct{}
+
+func (m *LogMailer) Send(to, subject, body string) error {
+	log.Printf("mail to=%s subject=%q\n%s", to, subject, body)
+	return nil
+}
+
+type SMTPMailer struct {
+	Addr string
+	Host string
+	User string
+	Pass string
+	From string
+}
+
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("mail headers must not contain line breaks")
+	}
+
+	msg := "From: " + m.From + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" +
+		body
+
+	var a smtp.Auth
+	if m.User != "" {
+		a = smtp.PlainAuth("", m.User, m.Pass, m.Host)
+	}
+
+	return smtp.SendMail(m.Addr, a, m.From, []string{to}, []byte(msg))
+}
@@ -0,0 +1,234 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+var isValidEventType = regexp.MustCompile(`^[a-z][a-z0-9_.]{0,49}$`).MatchString
+
+type Handler struct {
+	NotificationRepo types.NotificationRepository
+	UserRepo         types.UserRepository
+}
+
+func NewHandler(db *sql.DB) *Handler {
+	return &Handler{
+		NotificationRepo: NewNotificationStore(db),
+		UserRepo:         user.NewUserStore(db),
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc(
+		"/me/notifications",
+		auth.WithJWTAuth(h.handleGetNotifications, h.UserRepo),
+	).Methods("GET")
+	router.HandleFunc(
+		"/me/notifications/unread-count",
+		auth.WithJWTAuth(h.handleGetUnreadCount, h.UserRepo),
+	).Methods("GET")
+	router.HandleFunc(
+		"/me/notifications/read-all",
+		auth.WithJWTAuth(h.handleMarkAllRead, h.UserRepo),
+	).Methods("POST")
+	router.HandleFunc(
+		"/me/notifications/{id:[0-9]+}/read",
+		auth.WithJWTAuth(h.handleMarkRead, h.UserRepo),
+	).Methods("POST")
+	router.HandleFunc(
+		"/me/notification-preferences",
+		auth.WithJWTAuth(h.handleGetPreferences, h.UserRepo),
+	).Methods("GET")
+	router.HandleFunc(
+		"/me/notification-preferences/{eventType}",
+		auth.WithJWTAuth(h.handleUpdatePreference, h.UserRepo),
+	).Methods("PUT")
+}
+
+// @Summary List notifications
+// @Description List the authenticated user's notifications, newest first.
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only return unread notifications"
+// @Param page query int false "Page number (default 1)"
+// @Param pageSize query int false "Page size (default 20, max 100)"
+// @Success 200 {array} types.Notification "Notifications"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/notifications [get]
+func (h *Handler) handleGetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	limit, offset, err := utils.ParsePagination(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	unreadOnly := false
+	if u := r.URL.Query().Get("unread"); u != "" {
+		unreadOnly, err = strconv.ParseBool(u)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("unread must be a boolean"))
+			return
+		}
+	}
+
+	notifications, err := h.NotificationRepo.GetNotificationsByUserID(userID, unreadOnly, limit, offset)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, notifications)
+}
+
+// @Summary Count unread notifications
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.UnreadCountResponse "Unread count"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/notifications/unread-count [get]
+func (h *Handler) handleGetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	count, err := h.NotificationRepo.CountUnreadNotifications(userID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.UnreadCountResponse{Unread: count})
+}
+
+// @Summary Mark a notification as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 {object} types.SuccessResponse "Notification marked as read"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Router /api/v1/me/notifications/{id}/read [post]
+func (h *Handler) handleMarkRead(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	notificationID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid notification id"))
+		return
+	}
+
+	if err := h.NotificationRepo.MarkNotificationRead(userID, notificationID); err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Notification marked as read"})
+}
+
+// @Summary Mark all notifications as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.SuccessResponse "All notifications marked as read"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/notifications/read-all [post]
+func (h *Handler) handleMarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	if err := h.NotificationRepo.MarkAllNotificationsRead(userID); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(
+		w,
+		http.StatusOK,
+		types.SuccessResponse{Message: "All notifications marked as read"},
+	)
+}
+
+// @Summary List notification preferences
+// @Description List the channels the user chose per event type. Event types that are not listed use the default: in-app only.
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.NotificationPreference "Preferences"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/notification-preferences [get]
+func (h *Handler) handleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	prefs, err := h.NotificationRepo.GetNotificationPreferences(userID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, prefs)
+}
+
+// @Summary Set notification preference
+// @Description Choose the channels (in-app, email, digest) used for an event type.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param eventType path string true "Event type"
+// @Param preference body types.NotificationPreferenceRequest true "Channels"
+// @Success 200 {object} types.NotificationPreference "Updated preference"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/notification-preferences/{eventType} [put]
+func (h *Handler) handleUpdatePreference(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	eventType := mux.Vars(r)["eventType"]
+	if !isValidEventType(eventType) {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid event type"))
+		return
+	}
+
+	var req types.NotificationPreferenceRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	pref := &types.NotificationPreference{
+		EventType: eventType,
+		InApp:     *req.InApp,
+		Email:     *req.Email,
+		Digest:    *req.Digest,
+	}
+
+	if err := h.NotificationRepo.UpsertNotificationPreference(userID, pref); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, pref)
+}
@@ -0,0 +1,175 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/mailer"
+	"github.com/AyKrimino/JobSeekerAPI/service/realtime"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+// Service is the entry point domain code uses to notify a user. It looks up
+// the user's preference for the event type and delivers the notification to
+// each enabled channel. In-app notifications are also pushed to the user's
+// open event streams when a Broker is set.
+type Service struct {
+	Repo     types.NotificationRepository
+	UserRepo types.UserRepository
+	Mailer   mailer.Mailer
+	Broker   realtime.Broker
+}
+
+func NewService(
+	repo types.NotificationRepository,
+	userRepo types.UserRepository,
+	m mailer.Mailer,
+	broker realtime.Broker,
+) *Service {
+	return &Service{
+		Repo:     repo,
+		UserRepo: userRepo,
+		Mailer:   m,
+		Broker:   broker,
+	}
+}
+
+// DefaultPreference applies to event types a user has not configured:
+// in-app only.
+func DefaultPreference(eventType string) types.NotificationPreference {
+	return types.NotificationPreference{
+		EventType: eventType,
+		InApp:     true,
+	}
+}
+
+// Notify delivers n to n.UserID on the channels selected for n.Type.
+func (s *Service) Notify(n *types.Notification) error {
+	pref, err := s.Repo.GetNotificationPreference(n.UserID, n.Type)
+	if err != nil {
+		return err
+	}
+	if pref == nil {
+		p := DefaultPreference(n.Type)
+		pref = &p
+	}
+
+	if pref.InApp {
+		if err := s.Repo.CreateNotification(n); err != nil {
+			return err
+		}
+		if s.Broker != nil {
+			if err := s.Broker.Publish(n.UserID, realtime.EventNotification, n); err != nil {
+				log.Printf("failed to publish notification %d: %v", n.ID, err)
+			}
+		}
+	}
+
+	if pref.Digest {
+		if err := s.Repo.CreateDigestItem(n); err != nil {
+			return err
+		}
+	}
+
+	if pref.Email {
+		u, err := s.UserRepo.GetUserByID(n.UserID)
+		if err != nil {
+			return err
+		}
+		if err := s.Mailer.Send(u.Email, n.Title, formatEmailBody(n)); err != nil {
+			return fmt.Errorf("failed to email notification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// NotifyAsync is Notify for callers that should not fail because a
+// notification could not be delivered. Errors are logged.
+func (s *Service) NotifyAsync(n *types.Notification) {
+	go func() {
+		if err := s.Notify(n); err != nil {
+			log.Printf("failed to deliver %s notification to user %d: %v", n.Type, n.UserID, err)
+		}
+	}()
+}
+
+// SendDigests emails every user with pending digest items a single summary
+// and marks the items as sent.
+func (s *Service) SendDigests() error {
+	items, err := s.Repo.GetPendingDigestItems()
+	if err != nil {
+		return err
+	}
+
+	byUser := make(map[int][]types.Notification)
+	var userIDs []int
+	for _, item := range items {
+		if _, ok := byUser[item.UserID]; !ok {
+			userIDs = append(userIDs, item.UserID)
+		}
+		byUser[item.UserID] = append(byUser[item.UserID], item)
+	}
+
+	for _, userID := range userIDs {
+		userItems := byUser[userID]
+
+		u, err := s.UserRepo.GetUserByID(userID)
+		if err != nil {
+			log.Printf("skipping digest for user %d: %v", userID, err)
+			continue
+		}
+
+		var b strings.Builder
+		ids := make([]int, 0, len(userItems))
+		for _, item := range userItems {
+			b.WriteString("- ")
+			b.WriteString(formatEmailBody(&item))
+			b.WriteString("\n")
+			ids = append(ids, item.ID)
+		}
+
+		subject := fmt.Sprintf("Your JobSeeker digest: %d new updates", len(userItems))
+		if err := s.Mailer.Send(u.Email, subject, b.String()); err != nil {
+			log.Printf("failed to send digest to user %d: %v", userID, err)
+			continue
+		}
+
+		if err := s.Repo.MarkDigestItemsSent(ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunDigests calls SendDigests every interval until ctx is cancelled.
+func (s *Service) RunDigests(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.SendDigests(); err != nil {
+				log.Printf("failed to send notification digests: %v", err)
+			}
+		}
+	}
+}
+
+func formatEmailBody(n *types.Notification) string {
+	body := n.Title
+	if n.Body != "" {
+		body += "\n" + n.Body
+	}
+	if n.Link != "" {
+		body += "\n" + n.Link
+	}
+	return body
+}
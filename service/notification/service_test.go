@@ -0,0 +1,140 @@
+package notification_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/notification"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+type mockNotificationRepo struct {
+	types.NotificationRepository
+	prefs       map[string]*types.NotificationPreference
+	created     []types.Notification
+	digestItems []types.Notification
+	sentIDs     []int
+}
+
+func (m *mockNotificationRepo) GetNotificationPreference(
+	userID int,
+	eventType string,
+) (*types.NotificationPreference, error) {
+	return m.prefs[eventType], nil
+}
+
+func (m *mockNotificationRepo) CreateNotification(n *types.Notification) error {
+	m.created = append(m.created, *n)
+	return nil
+}
+
+func (m *mockNotificationRepo) CreateDigestItem(n *types.Notification) error {
+	item := *n
+	item.ID = len(m.digestItems) + 1
+	m.digestItems = append(m.digestItems, item)
+	return nil
+}
+
+func (m *mockNotificationRepo) GetPendingDigestItems() ([]types.Notification, error) {
+	return m.digestItems, nil
+}
+
+func (m *mockNotificationRepo) MarkDigestItemsSent(ids []int) error {
+	m.sentIDs = append(m.sentIDs, ids...)
+	return nil
+}
+
+type mockUserRepo struct {
+	types.UserRepository
+}
+
+func (m *mockUserRepo) GetUserByID(id int) (*types.User, error) {
+	return &types.User{ID: id, Email: fmt.Sprintf("user%d@test.com", id)}, nil
+}
+
+type sentMail struct {
+	to, subject, body string
+}
+
+type mockMailer struct {
+	sent []sentMail
+}
+
+func (m *mockMailer) Send(to, subject, body string) error {
+	m.sent = append(m.sent, sentMail{to, subject, body})
+	return nil
+}
+
+func TestNotify(t *testing.T) {
+	tests := []struct {
+		name          string
+		pref          *types.NotificationPreference
+		expectInApp   int
+		expectEmails  int
+		expectDigests int
+	}{
+		{"Default preference is in-app only", nil, 1, 0, 0},
+		{"Email only", &types.NotificationPreference{Email: true}, 0, 1, 0},
+		{"In-app and digest", &types.NotificationPreference{InApp: true, Digest: true}, 1, 0, 1},
+		{"All channels off", &types.NotificationPreference{}, 0, 0, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mockNotificationRepo{prefs: map[string]*types.NotificationPreference{}}
+			if tc.pref != nil {
+				repo.prefs["review.published"] = tc.pref
+			}
+			m := &mockMailer{}
+			svc := notification.NewService(repo, &mockUserRepo{}, m, nil)
+
+			err := svc.Notify(&types.Notification{
+				UserID: 7,
+				Type:   "review.published",
+				Title:  "Your review is live",
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if len(repo.created) != tc.expectInApp {
+				t.Errorf("expected %d in-app notifications, got %d", tc.expectInApp, len(repo.created))
+			}
+			if len(m.sent) != tc.expectEmails {
+				t.Errorf("expected %d emails, got %d", tc.expectEmails, len(m.sent))
+			}
+			if len(repo.digestItems) != tc.expectDigests {
+				t.Errorf("expected %d digest items, got %d", tc.expectDigests, len(repo.digestItems))
+			}
+			if tc.expectEmails > 0 && m.sent[0].to != "user7@test.com" {
+				t.Errorf("expected email to user7@test.com, got %s", m.sent[0].to)
+			}
+		})
+	}
+}
+
+func TestSendDigests(t *testing.T) {
+	repo := &mockNotificationRepo{
+		digestItems: []types.Notification{
+			{ID: 1, UserID: 1, Title: "First"},
+			{ID: 2, UserID: 2, Title: "Second"},
+			{ID: 3, UserID: 1, Title: "Third"},
+		},
+	}
+	m := &mockMailer{}
+	svc := notification.NewService(repo, &mockUserRepo{}, m, nil)
+
+	if err := svc.SendDigests(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(m.sent) != 2 {
+		t.Fatalf("expected one digest per user, got %d emails", len(m.sent))
+	}
+	if m.sent[0].to != "user1@test.com" || m.sent[0].body != "- First\n- Third\n" {
+		t.Errorf("unexpected digest for user 1: %+v", m.sent[0])
+	}
+	if len(repo.sentIDs) != 3 {
+		t.Errorf("expected all 3 items marked as sent, got %v", repo.sentIDs)
+	}
+}
@@ -0,0 +1,263 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+type notificationStore struct {
+	db *sql.DB
+}
+
+func NewNotificationStore(db *sql.DB) types.NotificationRepository {
+	return &notificationStore{
+		db: db,
+	}
+}
+
+func (s *notificationStore) CreateNotification(n *types.Notification) error {
+	res, err := s.db.Exec(
+		"INSERT INTO Notification (userID, type, title, body, link) VALUES (?, ?, ?, ?, ?)",
+		n.UserID,
+		n.Type,
+		n.Title,
+		n.Body,
+		n.Link,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	n.ID = int(id)
+
+	return nil
+}
+
+func (s *notificationStore) GetNotificationsByUserID(
+	userID int,
+	unreadOnly bool,
+	limit, offset int,
+) ([]types.Notification, error) {
+	rows, err := s.db.Query(
+		`SELECT id, userID, type, title, COALESCE(body, ''), COALESCE(link, ''), readAt, createdAt
+		FROM Notification
+		WHERE userID = ? AND (? = FALSE OR readAt IS NULL)
+		ORDER BY createdAt DESC, id DESC
+		LIMIT ? OFFSET ?`,
+		userID,
+		unreadOnly,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]types.Notification, 0)
+	for rows.Next() {
+		n, err := scanRowsIntoNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, *n)
+	}
+
+	return notifications, rows.Err()
+}
+
+func (s *notificationStore) CountUnreadNotifications(userID int) (int, error) {
+	var count int
+	err := s.db.QueryRow(
+		"SELECT COUNT(*) FROM Notification WHERE userID = ? AND readAt IS NULL",
+		userID,
+	).Scan(&count)
+
+	return count, err
+}
+
+func (s *notificationStore) MarkNotificationRead(userID, notificationID int) error {
+	res, err := s.db.Exec(
+		"UPDATE Notification SET readAt = COALESCE(readAt, UTC_TIMESTAMP()) WHERE id = ? AND userID = ?",
+		notificationID,
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Rows already read count as unaffected, so check existence separately.
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		var exists bool
+		err := s.db.QueryRow(
+			"SELECT EXISTS(SELECT 1 FROM Notification WHERE id = ? AND userID = ?)",
+			notificationID,
+			userID,
+		).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("notification not found")
+		}
+	}
+
+	return nil
+}
+
+func (s *notificationStore) MarkAllNotificationsRead(userID int) error {
+	_, err := s.db.Exec(
+		"UPDATE Notification SET readAt = UTC_TIMESTAMP() WHERE userID = ? AND readAt IS NULL",
+		userID,
+	)
+
+	return err
+}
+
+func (s *notificationStore) GetNotificationPreferences(userID int) ([]types.NotificationPreference, error) {
+	rows, err := s.db.Query(
+		"SELECT eventType, inApp, email, digest FROM NotificationPreference WHERE userID = ? ORDER BY eventType",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prefs := make([]types.NotificationPreference, 0)
+	for rows.Next() {
+		var p types.NotificationPreference
+		if err := rows.Scan(&p.EventType, &p.InApp, &p.Email, &p.Digest); err != nil {
+			return nil, err
+		}
+		prefs = append(prefs, p)
+	}
+
+	return prefs, rows.Err()
+}
+
+// GetNotificationPreference returns the stored preference for an event type,
+// or nil when the user has not changed the defaults.
+func (s *notificationStore) GetNotificationPreference(
+	userID int,
+	eventType string,
+) (*types.NotificationPreference, error) {
+	p := &types.NotificationPreference{EventType: eventType}
+
+	err := s.db.QueryRow(
+		"SELECT inApp, email, digest FROM NotificationPreference WHERE userID = ? AND eventType = ?",
+		userID,
+		eventType,
+	).Scan(&p.InApp, &p.Email, &p.Digest)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *notificationStore) UpsertNotificationPreference(userID int, p *types.NotificationPreference) error {
+	_, err := s.db.Exec(
+		`INSERT INTO NotificationPreference (userID, eventType, inApp, email, digest)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE inApp = VALUES(inApp), email = VALUES(email), digest = VALUES(digest)`,
+		userID,
+		p.EventType,
+		p.InApp,
+		p.Email,
+		p.Digest,
+	)
+
+	return err
+}
+
+func (s *notificationStore) CreateDigestItem(n *types.Notification) error {
+	_, err := s.db.Exec(
+		"INSERT INTO NotificationDigestItem (userID, type, title, body, link) VALUES (?, ?, ?, ?, ?)",
+		n.UserID,
+		n.Type,
+		n.Title,
+		n.Body,
+		n.Link,
+	)
+
+	return err
+}
+
+func (s *notificationStore) GetPendingDigestItems() ([]types.Notification, error) {
+	rows, err := s.db.Query(
+		`SELECT id, userID, type, title, COALESCE(body, ''), COALESCE(link, ''), NULL, createdAt
+		FROM NotificationDigestItem
+		WHERE sentAt IS NULL
+		ORDER BY userID, createdAt, id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]types.Notification, 0)
+	for rows.Next() {
+		n, err := scanRowsIntoNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *n)
+	}
+
+	return items, rows.Err()
+}
+
+func (s *notificationStore) MarkDigestItemsSent(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	_, err := s.db.Exec(
+		"UPDATE NotificationDigestItem SET sentAt = UTC_TIMESTAMP() WHERE id IN ("+placeholders+")",
+		args...,
+	)
+
+	return err
+}
+
+func scanRowsIntoNotification(rows *sql.Rows) (*types.Notification, error) {
+	n := new(types.Notification)
+
+	var readAt sql.NullTime
+	err := rows.Scan(
+		&n.ID,
+		&n.UserID,
+		&n.Type,
+		&n.Title,
+		&n.Body,
+		&n.Link,
+		&readAt,
+		&n.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if readAt.Valid {
+		n.ReadAt = &readAt.Time
+	}
+
+	return n, nil
+}
@@ -0,0 +1,101 @@
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/notification"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/testutils"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+func TestNotificationStore_ReadState(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userID, err := user.NewUserStore(db).CreateUser(&types.User{
+		Email:    "notify@test.com",
+		Password: "Pass1234",
+		Role:     "JobSeeker",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+
+	store := notification.NewNotificationStore(db)
+
+	first := &types.Notification{UserID: userID, Type: "test.event", Title: "First"}
+	second := &types.Notification{UserID: userID, Type: "test.event", Title: "Second"}
+	for _, n := range []*types.Notification{first, second} {
+		if err := store.CreateNotification(n); err != nil {
+			t.Fatal("CreateNotification failed:", err)
+		}
+	}
+
+	if err := store.MarkNotificationRead(userID, first.ID); err != nil {
+		t.Fatal("MarkNotificationRead failed:", err)
+	}
+
+	count, err := store.CountUnreadNotifications(userID)
+	if err != nil {
+		t.Fatal("CountUnreadNotifications failed:", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 unread notification, got %d", count)
+	}
+
+	unread, err := store.GetNotificationsByUserID(userID, true, 10, 0)
+	if err != nil {
+		t.Fatal("GetNotificationsByUserID failed:", err)
+	}
+	if len(unread) != 1 || unread[0].ID != second.ID {
+		t.Errorf("expected only the second notification, got %v", unread)
+	}
+
+	if err := store.MarkNotificationRead(userID+1, second.ID); err == nil {
+		t.Error("expected an error marking another user's notification")
+	}
+}
+
+func TestNotificationStore_Preferences(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userID, err := user.NewUserStore(db).CreateUser(&types.User{
+		Email:    "prefs@test.com",
+		Password: "Pass1234",
+		Role:     "Company",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+
+	store := notification.NewNotificationStore(db)
+
+	pref, err := store.GetNotificationPreference(userID, "test.event")
+	if err != nil {
+		t.Fatal("GetNotificationPreference failed:", err)
+	}
+	if pref != nil {
+		t.Fatalf("expected no stored preference, got %+v", pref)
+	}
+
+	for _, email := range []bool{true, false} {
+		err := store.UpsertNotificationPreference(userID, &types.NotificationPreference{
+			EventType: "test.event",
+			InApp:     true,
+			Email:     email,
+		})
+		if err != nil {
+			t.Fatal("UpsertNotificationPreference failed:", err)
+		}
+	}
+
+	prefs, err := store.GetNotificationPreferences(userID)
+	if err != nil {
+		t.Fatal("GetNotificationPreferences failed:", err)
+	}
+	if len(prefs) != 1 || prefs[0].Email {
+		t.Errorf("expected a single preference with email disabled, got %+v", prefs)
+	}
+}
@@ -0,0 +1,233 @@
+package position
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+type Handler struct {
+	PositionRepo  types.PositionRepository
+	JobSeekerRepo types.JobSeekerRepository
+	UserRepo      types.UserRepository
+}
+
+func NewHandler(db *sql.DB) *Handler {
+	return &Handler{
+		PositionRepo:  NewPositionStore(db),
+		JobSeekerRepo: jobseeker.NewJobseekerStore(db),
+		UserRepo:      user.NewUserStore(db),
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/me/positions", auth.WithJWTAuth(h.handleGetPositions, h.UserRepo)).Methods("GET")
+	router.HandleFunc("/me/positions", auth.WithJWTAuth(h.handleCreatePosition, h.UserRepo)).Methods("POST")
+	router.HandleFunc(
+		"/me/positions/{positionID:[0-9]+}",
+		auth.WithJWTAuth(h.handleUpdatePosition, h.UserRepo),
+	).Methods("PUT")
+	router.HandleFunc(
+		"/me/positions/{positionID:[0-9]+}",
+		auth.WithJWTAuth(h.handleDeletePosition, h.UserRepo),
+	).Methods("DELETE")
+}
+
+// @Summary List work history
+// @Description List the authenticated job seeker's positions, current and most recent first.
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.Position "Positions"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/positions [get]
+func (h *Handler) handleGetPositions(w http.ResponseWriter, r *http.Request) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	positions, err := h.PositionRepo.GetPositionsByJobSeekerID(js.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, positions)
+}
+
+// @Summary Add a position
+// @Description Add a position to the authenticated job seeker's work history. Total experience on the profile is recomputed from all positions.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param position body types.PositionRequest true "Position. Leave endDate out for a current position."
+// @Success 201 {object} types.Position "Position created"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/positions [post]
+func (h *Handler) handleCreatePosition(w http.ResponseWriter, r *http.Request) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	req, ok := parsePositionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	p := &types.Position{JobSeekerID: js.ID}
+	applyPositionRequest(p, req)
+
+	if err := h.PositionRepo.CreatePosition(p); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, p)
+}
+
+// @Summary Update a position
+// @Description Replace a position in the authenticated job seeker's work history.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param positionID path int true "Position ID"
+// @Param position body types.PositionRequest true "Position"
+// @Success 200 {object} types.Position "Position updated"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/positions/{positionID} [put]
+func (h *Handler) handleUpdatePosition(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.positionFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	req, ok := parsePositionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	applyPositionRequest(p, req)
+
+	if err := h.PositionRepo.UpdatePosition(p); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, p)
+}
+
+// @Summary Delete a position
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Param positionID path int true "Position ID"
+// @Success 200 {object} types.SuccessResponse "Position deleted"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/positions/{positionID} [delete]
+func (h *Handler) handleDeletePosition(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.positionFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.PositionRepo.DeletePosition(p); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Position deleted"})
+}
+
+func (h *Handler) jobSeekerFromContext(w http.ResponseWriter, r *http.Request) (*types.JobSeeker, bool) {
+	if !strings.EqualFold(auth.GetUserRoleFromContext(r.Context()), "JobSeeker") {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("only job seekers have a work history"))
+		return nil, false
+	}
+
+	js, err := h.JobSeekerRepo.GetJobSeekerByUserID(auth.GetUserIDFromContext(r.Context()))
+	if err != nil {
+		utils.WriteError(w, http.StatusForbidden, err)
+		return nil, false
+	}
+
+	return js, true
+}
+
+// positionFromPath loads the position in the URL, answering 404 when it
+// belongs to someone else.
+func (h *Handler) positionFromPath(w http.ResponseWriter, r *http.Request) (*types.Position, bool) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	positionID, err := strconv.Atoi(mux.Vars(r)["positionID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid position id"))
+		return nil, false
+	}
+
+	p, err := h.PositionRepo.GetPositionByID(positionID)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+	if p.JobSeekerID != js.ID {
+		utils.WriteError(w, http.StatusNotFound, fmt.Errorf("position not found"))
+		return nil, false
+	}
+
+	return p, true
+}
+
+func parsePositionRequest(w http.ResponseWriter, r *http.Request) (*types.PositionRequest, bool) {
+	var req types.PositionRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return nil, false
+	}
+
+	// YYYY-MM-DD dates order the same as strings.
+	if req.EndDate != nil && *req.EndDate < req.StartDate {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("endDate must not be before startDate"))
+		return nil, false
+	}
+
+	return &req, true
+}
+
+func applyPositionRequest(p *types.Position, req *types.PositionRequest) {
+	p.Title = req.Title
+	p.Employer = req.Employer
+	p.StartDate = req.StartDate
+	p.EndDate = req.EndDate
+	p.Description = req.Description
+	p.Skills = req.Skills
+}
@@ -0,0 +1,389 @@
+package position
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/skill"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+)
+
+const (
+	dateLayout         = "2006-01-02"
+	maxExperienceYears = 50
+)
+
+type positionStore struct {
+	db *sql.DB
+}
+
+func NewPositionStore(db *sql.DB) types.PositionRepository {
+	return &positionStore{
+		db: db,
+	}
+}
+
+// GetPositionsByJobSeekerID lists a job seeker's work history, most recent
+// first.
+func (s *positionStore) GetPositionsByJobSeekerID(jobSeekerID int) ([]types.Position, error) {
+	rows, err := s.db.Query(
+		selectPosition+" WHERE jobSeekerID = ? ORDER BY endDate IS NULL DESC, endDate DESC, startDate DESC, id DESC",
+		jobSeekerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	positions := make([]types.Position, 0)
+	for rows.Next() {
+		p, err := scanRowsIntoPosition(rows)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	skills, err := s.getSkillsByJobSeekerID(jobSeekerID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range positions {
+		if names, ok := skills[positions[i].ID]; ok {
+			positions[i].Skills = names
+		}
+	}
+
+	return positions, nil
+}
+
+func (s *positionStore) GetPositionByID(id int) (*types.Position, error) {
+	rows, err := s.db.Query(selectPosition+" WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	p := new(types.Position)
+	for rows.Next() {
+		p, err = scanRowsIntoPosition(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.ID == 0 {
+		return nil, fmt.Errorf("position not found")
+	}
+
+	skills, err := s.getSkillsByJobSeekerID(p.JobSeekerID)
+	if err != nil {
+		return nil, err
+	}
+	if names, ok := skills[p.ID]; ok {
+		p.Skills = names
+	}
+
+	return p, nil
+}
+
+// CreatePosition stores the position with its skills and recomputes the
+// job seeker's total experience.
+func (s *positionStore) CreatePosition(p *types.Position) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		"INSERT INTO Position (jobSeekerID, title, employer, startDate, endDate, description) VALUES (?, ?, ?, ?, ?, ?)",
+		p.JobSeekerID,
+		p.Title,
+		p.Employer,
+		p.StartDate,
+		p.EndDate,
+		p.Description,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	p.ID = int(id)
+
+	if err := setPositionSkills(tx, p); err != nil {
+		return err
+	}
+
+	if err := recomputeExperience(tx, p.JobSeekerID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *positionStore) UpdatePosition(p *types.Position) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		"UPDATE Position SET title = ?, employer = ?, startDate = ?, endDate = ?, description = ? WHERE id = ?",
+		p.Title,
+		p.Employer,
+		p.StartDate,
+		p.EndDate,
+		p.Description,
+		p.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM PositionSkill WHERE positionID = ?", p.ID); err != nil {
+		return err
+	}
+
+	if err := setPositionSkills(tx, p); err != nil {
+		return err
+	}
+
+	if err := recomputeExperience(tx, p.JobSeekerID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *positionStore) DeletePosition(p *types.Position) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM Position WHERE id = ?", p.ID); err != nil {
+		return err
+	}
+
+	if err := recomputeExperience(tx, p.JobSeekerID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *positionStore) getSkillsByJobSeekerID(jobSeekerID int) (map[int][]string, error) {
+	rows, err := s.db.Query(
+		`SELECT ps.positionID, s.name
+		FROM PositionSkill ps
+		JOIN Position p ON p.id = ps.positionID
+		JOIN Skill s ON s.id = ps.skillID
+		WHERE p.jobSeekerID = ?
+		ORDER BY s.name`,
+		jobSeekerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := make(map[int][]string)
+	for rows.Next() {
+		var (
+			positionID int
+			name       string
+		)
+		if err := rows.Scan(&positionID, &name); err != nil {
+			return nil, err
+		}
+		skills[positionID] = append(skills[positionID], name)
+	}
+
+	return skills, rows.Err()
+}
+
+func setPositionSkills(q skill.Querier, p *types.Position) error {
+	skills, err := skill.ResolveSkills(q, p.Skills)
+	if err != nil {
+		return fmt.Errorf("error resolving skills: %v", err)
+	}
+
+	for _, sk := range skills {
+		_, err := q.Exec(
+			"INSERT INTO PositionSkill (positionID, skillID) VALUES (?, ?)",
+			p.ID,
+			sk.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.Skills = skill.Names(skills)
+
+	return nil
+}
+
+// RefreshExperience recomputes the experience of job seekers with an
+// open-ended position, which grows as time passes without any write to
+// their history.
+func (s *positionStore) RefreshExperience() error {
+	rows, err := s.db.Query("SELECT DISTINCT jobSeekerID FROM Position WHERE endDate IS NULL")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := recomputeExperience(s.db, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunExperienceRefresh calls RefreshExperience every interval until ctx is
+// cancelled.
+func RunExperienceRefresh(ctx context.Context, repo types.PositionRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := repo.RefreshExperience(); err != nil {
+				log.Printf("failed to refresh experience: %v", err)
+			}
+		}
+	}
+}
+
+// recomputeExperience stores the experience derived from the job seeker's
+// positions in JobSeeker.experience, where profile reads and searches
+// expect it. Without positions the experience stated at registration is
+// restored.
+func recomputeExperience(q skill.Querier, jobSeekerID int) error {
+	rows, err := q.Query(
+		"SELECT DATE_FORMAT(startDate, '%Y-%m-%d'), DATE_FORMAT(endDate, '%Y-%m-%d') FROM Position WHERE jobSeekerID = ?",
+		jobSeekerID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var positions []types.Position
+	for rows.Next() {
+		var (
+			p       types.Position
+			endDate sql.NullString
+		)
+		if err := rows.Scan(&p.StartDate, &endDate); err != nil {
+			return err
+		}
+		if endDate.Valid {
+			p.EndDate = &endDate.String
+		}
+		positions = append(positions, p)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(positions) == 0 {
+		_, err = q.Exec("UPDATE JobSeeker SET experience = statedExperience WHERE id = ?", jobSeekerID)
+		return err
+	}
+
+	_, err = q.Exec(
+		"UPDATE JobSeeker SET experience = ? WHERE id = ?",
+		ExperienceYears(positions, time.Now().UTC()),
+		jobSeekerID,
+	)
+
+	return err
+}
+
+// ExperienceYears returns the whole years covered by positions, counting
+// overlapping positions once and both the first and last month of each.
+// Positions without an end date run until now.
+func ExperienceYears(positions []types.Position, now time.Time) int {
+	nowMonth := utils.MonthNumber(now)
+	ranges := make([]utils.MonthRange, 0, len(positions))
+	for _, p := range positions {
+		start, err := time.Parse(dateLayout, p.StartDate)
+		if err != nil {
+			continue
+		}
+
+		end := nowMonth
+		if p.EndDate != nil {
+			t, err := time.Parse(dateLayout, *p.EndDate)
+			if err != nil {
+				continue
+			}
+			end = min(utils.MonthNumber(t), nowMonth)
+		}
+
+		ranges = append(ranges, utils.MonthRange{Start: utils.MonthNumber(start), End: end})
+	}
+
+	return min(utils.CoveredMonths(ranges)/12, maxExperienceYears)
+}
+
+const selectPosition = `SELECT id, jobSeekerID, title, employer, DATE_FORMAT(startDate, '%Y-%m-%d'),
+	DATE_FORMAT(endDate, '%Y-%m-%d'), COALESCE(description, '')
+	FROM Position`
+
+func scanRowsIntoPosition(rows *sql.Rows) (*types.Position, error) {
+	p := &types.Position{Skills: []string{}}
+
+	var endDate sql.NullString
+	err := rows.Scan(
+		&p.ID,
+		&p.JobSeekerID,
+		&p.Title,
+		&p.Employer,
+		&p.StartDate,
+		&endDate,
+		&p.Description,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if endDate.Valid {
+		p.EndDate = &endDate.String
+	}
+
+	return p, nil
+}
@@ -0,0 +1,159 @@
+package position_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
+	"github.com/AyKrimino/JobSeekerAPI/service/position"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/testutils"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+func date(s string) *string {
+	return &s
+}
+
+func TestExperienceYears(t *testing.T) {
+	now := time.Date(2025, time.April, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		positions []types.Position
+		want      int
+	}{
+		{"no positions", nil, 0},
+		{
+			"single closed position",
+			[]types.Position{{StartDate: "2018-01-01", EndDate: date("2021-01-01")}},
+			3,
+		},
+		{
+			"first and last months are both counted",
+			[]types.Position{{StartDate: "2020-01-01", EndDate: date("2020-12-01")}},
+			1,
+		},
+		{
+			"current position runs until now",
+			[]types.Position{{StartDate: "2020-04-01"}},
+			5,
+		},
+		{
+			"overlapping positions count once",
+			[]types.Position{
+				{StartDate: "2015-01-01", EndDate: date("2019-01-01")},
+				{StartDate: "2017-01-01", EndDate: date("2020-01-01")},
+			},
+			5,
+		},
+		{
+			"gaps are not counted",
+			[]types.Position{
+				{StartDate: "2010-01-01", EndDate: date("2012-01-01")},
+				{StartDate: "2020-01-01", EndDate: date("2022-06-01")},
+			},
+			4,
+		},
+		{
+			"future end dates are capped at now",
+			[]types.Position{{StartDate: "2023-04-01", EndDate: date("2030-01-01")}},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := position.ExperienceYears(tt.positions, now); got != tt.want {
+				t.Errorf("expected %d years, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPositionStore_RecomputesExperience(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userID, err := user.NewUserStore(db).CreateUser(&types.User{
+		Email:    "history@test.com",
+		Password: "Pass1234",
+		Role:     "JobSeeker",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+
+	jsStore := jobseeker.NewJobseekerStore(db)
+	js := &types.JobSeeker{FirstName: "fname", LastName: "lname", Experience: 2, UserID: userID}
+	if err := jsStore.CreateJobSeeker(js); err != nil {
+		t.Fatal("CreateJobSeeker failed:", err)
+	}
+
+	store := position.NewPositionStore(db)
+
+	p := &types.Position{
+		JobSeekerID: js.ID,
+		Title:       "Engineer",
+		Employer:    "Acme",
+		StartDate:   "2015-01-01",
+		EndDate:     date("2018-01-01"),
+		Skills:      []string{"golang"},
+	}
+	if err := store.CreatePosition(p); err != nil {
+		t.Fatal("CreatePosition failed:", err)
+	}
+
+	got, err := jsStore.GetJobSeekerByUserID(userID)
+	if err != nil {
+		t.Fatal("GetJobSeekerByUserID failed:", err)
+	}
+	if got.Experience != 3 {
+		t.Errorf("expected 3 years of experience, got %d", got.Experience)
+	}
+
+	positions, err := store.GetPositionsByJobSeekerID(js.ID)
+	if err != nil {
+		t.Fatal("GetPositionsByJobSeekerID failed:", err)
+	}
+	if len(positions) != 1 || len(positions[0].Skills) != 1 || positions[0].Skills[0] != "Go" {
+		t.Errorf("expected one position using Go, got %+v", positions)
+	}
+
+	if err := store.DeletePosition(p); err != nil {
+		t.Fatal("DeletePosition failed:", err)
+	}
+
+	got, err = jsStore.GetJobSeekerByUserID(userID)
+	if err != nil {
+		t.Fatal("GetJobSeekerByUserID failed:", err)
+	}
+	if got.Experience != 2 {
+		t.Errorf("expected the stated 2 years after deleting the position, got %d", got.Experience)
+	}
+
+	current := &types.Position{
+		JobSeekerID: js.ID,
+		Title:       "Engineer",
+		Employer:    "Acme",
+		StartDate:   time.Now().UTC().AddDate(-4, 0, 0).Format("2006-01-02"),
+	}
+	if err := store.CreatePosition(current); err != nil {
+		t.Fatal("CreatePosition failed:", err)
+	}
+	if _, err := db.Exec("UPDATE JobSeeker SET experience = 0 WHERE id = ?", js.ID); err != nil {
+		t.Fatal("resetting experience failed:", err)
+	}
+
+	if err := store.RefreshExperience(); err != nil {
+		t.Fatal("RefreshExperience failed:", err)
+	}
+
+	got, err = jsStore.GetJobSeekerByUserID(userID)
+	if err != nil {
+		t.Fatal("GetJobSeekerByUserID failed:", err)
+	}
+	if got.Experience != 4 {
+		t.Errorf("expected the current position to be refreshed to 4 years, got %d", got.Experience)
+	}
+}
@@ -0,0 +1,243 @@
+package privacy
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+type Handler struct {
+	PrivacyRepo   types.PrivacyRepository
+	JobSeekerRepo types.JobSeekerRepository
+	CompanyRepo   types.CompanyRepository
+	UserRepo      types.UserRepository
+}
+
+func NewHandler(db *sql.DB) *Handler {
+	return &Handler{
+		PrivacyRepo:   NewPrivacyStore(db),
+		JobSeekerRepo: jobseeker.NewJobseekerStore(db),
+		CompanyRepo:   company.NewCompany(db),
+		UserRepo:      user.NewUserStore(db),
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/me/privacy", auth.WithJWTAuth(h.handleGetPrivacy, h.UserRepo)).Methods("GET")
+	router.HandleFunc("/me/privacy", auth.WithJWTAuth(h.handleUpdatePrivacy, h.UserRepo)).Methods("PATCH")
+	router.HandleFunc("/me/blocks", auth.WithJWTAuth(h.handleGetBlocks, h.UserRepo)).Methods("GET")
+	router.HandleFunc("/me/blocks", auth.WithJWTAuth(h.handleCreateBlock, h.UserRepo)).Methods("POST")
+	router.HandleFunc(
+		"/me/blocks/{blockID:[0-9]+}",
+		auth.WithJWTAuth(h.handleDeleteBlock, h.UserRepo),
+	).Methods("DELETE")
+}
+
+// @Summary Get privacy settings
+// @Tags privacy
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.PrivacySettings "Privacy settings"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/privacy [get]
+func (h *Handler) handleGetPrivacy(w http.ResponseWriter, r *http.Request) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	settings, err := h.PrivacyRepo.GetPrivacySettings(js.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, settings)
+}
+
+// @Summary Update privacy settings
+// @Description Set who can find the authenticated job seeker (public, companies or hidden) and whether they are open to work. Omitted fields are left unchanged.
+// @Tags privacy
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param settings body types.UpdatePrivacyRequest true "Settings to change"
+// @Success 200 {object} types.PrivacySettings "Updated settings"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/privacy [patch]
+func (h *Handler) handleUpdatePrivacy(w http.ResponseWriter, r *http.Request) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	var req types.UpdatePrivacyRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	settings, err := h.PrivacyRepo.GetPrivacySettings(js.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if req.Visibility != nil {
+		settings.Visibility = *req.Visibility
+	}
+	if req.OpenToWork != nil {
+		settings.OpenToWork = *req.OpenToWork
+	}
+
+	if err := h.PrivacyRepo.UpdatePrivacySettings(js.ID, settings); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, settings)
+}
+
+// @Summary List blocks
+// @Description List the companies and email domains the authenticated job seeker is hidden from.
+// @Tags privacy
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.JobSeekerBlock "Blocks"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/blocks [get]
+func (h *Handler) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	blocks, err := h.PrivacyRepo.GetBlocks(js.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, blocks)
+}
+
+// @Summary Block a company
+// @Description Hide the authenticated job seeker from a company, or from every company with a member at an email domain, such as a current employer.
+// @Tags privacy
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param block body types.CreateBlockRequest true "Either companyId or emailDomain"
+// @Success 201 {object} types.JobSeekerBlock "Block created"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/blocks [post]
+func (h *Handler) handleCreateBlock(w http.ResponseWriter, r *http.Request) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	var req types.CreateBlockRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req.EmailDomain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(req.EmailDomain), "@"))
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	b := &types.JobSeekerBlock{JobSeekerID: js.ID, EmailDomain: req.EmailDomain}
+	if req.CompanyID != 0 {
+		cpy, err := h.CompanyRepo.GetCompanyByID(req.CompanyID)
+		if err != nil {
+			utils.WriteError(w, http.StatusNotFound, err)
+			return
+		}
+		b.CompanyID = &cpy.ID
+		b.CompanyName = cpy.Name
+	}
+
+	err := h.PrivacyRepo.CreateBlock(b)
+	if errors.Is(err, ErrAlreadyBlocked) {
+		utils.WriteError(w, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, b)
+}
+
+// @Summary Remove a block
+// @Tags privacy
+// @Produce json
+// @Security BearerAuth
+// @Param blockID path int true "Block ID"
+// @Success 200 {object} types.SuccessResponse "Block removed"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Router /api/v1/me/blocks/{blockID} [delete]
+func (h *Handler) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
+	js, ok := h.jobSeekerFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	blockID, err := strconv.Atoi(mux.Vars(r)["blockID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid block id"))
+		return
+	}
+
+	if err := h.PrivacyRepo.DeleteBlock(js.ID, blockID); err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Block removed"})
+}
+
+func (h *Handler) jobSeekerFromContext(w http.ResponseWriter, r *http.Request) (*types.JobSeeker, bool) {
+	if !strings.EqualFold(auth.GetUserRoleFromContext(r.Context()), "JobSeeker") {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("only job seekers have privacy settings"))
+		return nil, false
+	}
+
+	js, err := h.JobSeekerRepo.GetJobSeekerByUserID(auth.GetUserIDFromContext(r.Context()))
+	if err != nil {
+		utils.WriteError(w, http.StatusForbidden, err)
+		return nil, false
+	}
+
+	return js, true
+}
@@ -0,0 +1,168 @@
+package privacy
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/go-sql-driver/mysql"
+)
+
+const mysqlErrDuplicateEntry = 1062
+
+var ErrAlreadyBlocked = errors.New("already blocked")
+
+// VisibleToCompany is a SQL condition on a JobSeeker aliased js. It holds
+// when the company bound to its placeholders may see the job seeker: the
+// profile is not hidden, and the job seeker has blocked neither the company
+// nor the email domain of any of its members. Every company-facing query
+// over job seekers must include it, with VisibleToCompanyArgs as arguments.
+const VisibleToCompany = `(js.visibility <> 'hidden' AND NOT EXISTS (
+	SELECT 1 FROM JobSeekerBlock b
+	WHERE b.jobSeekerID = js.id AND (
+		b.companyID = ?
+		OR b.emailDomain IN (
+			SELECT SUBSTRING_INDEX(u.email, '@', -1)
+			FROM CompanyMember m
+			JOIN User u ON u.id = m.userID
+			WHERE m.companyID = ?
+		)
+	)
+))`
+
+func VisibleToCompanyArgs(companyID int) []any {
+	return []any{companyID, companyID}
+}
+
+type privacyStore struct {
+	db *sql.DB
+}
+
+func NewPrivacyStore(db *sql.DB) types.PrivacyRepository {
+	return &privacyStore{
+		db: db,
+	}
+}
+
+func (s *privacyStore) GetPrivacySettings(jobSeekerID int) (*types.PrivacySettings, error) {
+	settings := new(types.PrivacySettings)
+
+	err := s.db.QueryRow(
+		"SELECT visibility, openToWork FROM JobSeeker WHERE id = ?",
+		jobSeekerID,
+	).Scan(&settings.Visibility, &settings.OpenToWork)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("job seeker not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (s *privacyStore) UpdatePrivacySettings(jobSeekerID int, settings *types.PrivacySettings) error {
+	_, err := s.db.Exec(
+		"UPDATE JobSeeker SET visibility = ?, openToWork = ? WHERE id = ?",
+		settings.Visibility,
+		settings.OpenToWork,
+		jobSeekerID,
+	)
+
+	return err
+}
+
+func (s *privacyStore) GetBlocks(jobSeekerID int) ([]types.JobSeekerBlock, error) {
+	rows, err := s.db.Query(
+		`SELECT b.id, b.jobSeekerID, b.companyID, COALESCE(c.name, ''), COALESCE(b.emailDomain, ''), b.createdAt
+		FROM JobSeekerBlock b
+		LEFT JOIN Company c ON c.id = b.companyID
+		WHERE b.jobSeekerID = ?
+		ORDER BY b.createdAt DESC, b.id DESC`,
+		jobSeekerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocks := make([]types.JobSeekerBlock, 0)
+	for rows.Next() {
+		var (
+			b         types.JobSeekerBlock
+			companyID sql.NullInt64
+		)
+		err := rows.Scan(&b.ID, &b.JobSeekerID, &companyID, &b.CompanyName, &b.EmailDomain, &b.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if companyID.Valid {
+			id := int(companyID.Int64)
+			b.CompanyID = &id
+		}
+		blocks = append(blocks, b)
+	}
+
+	return blocks, rows.Err()
+}
+
+func (s *privacyStore) CreateBlock(b *types.JobSeekerBlock) error {
+	var emailDomain sql.NullString
+	if b.EmailDomain != "" {
+		emailDomain = sql.NullString{String: b.EmailDomain, Valid: true}
+	}
+
+	res, err := s.db.Exec(
+		"INSERT INTO JobSeekerBlock (jobSeekerID, companyID, emailDomain) VALUES (?, ?, ?)",
+		b.JobSeekerID,
+		b.CompanyID,
+		emailDomain,
+	)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return ErrAlreadyBlocked
+	}
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	b.ID = int(id)
+
+	return nil
+}
+
+func (s *privacyStore) DeleteBlock(jobSeekerID, blockID int) error {
+	res, err := s.db.Exec(
+		"DELETE FROM JobSeekerBlock WHERE id = ? AND jobSeekerID = ?",
+		blockID,
+		jobSeekerID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("block not found")
+	}
+
+	return nil
+}
+
+// IsVisibleToCompany applies VisibleToCompany to a single job seeker, for
+// company-facing reads by ID.
+func (s *privacyStore) IsVisibleToCompany(jobSeekerID, companyID int) (bool, error) {
+	var visible bool
+
+	args := append([]any{jobSeekerID}, VisibleToCompanyArgs(companyID)...)
+	err := s.db.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM JobSeeker js WHERE js.id = ? AND "+VisibleToCompany+")",
+		args...,
+	).Scan(&visible)
+
+	return visible, err
+}
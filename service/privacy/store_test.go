@@ -0,0 +1,91 @@
+package privacy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
+	"github.com/AyKrimino/JobSeekerAPI/service/privacy"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/testutils"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+func TestPrivacyStore_Visibility(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userStore := user.NewUserStore(db)
+
+	ownerID, err := userStore.CreateUser(&types.User{
+		Email:    "hr@acme.com",
+		Password: "Pass1234",
+		Role:     "Company",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+	cpy := &types.Company{Name: "Acme", UserID: ownerID}
+	if err := company.NewCompany(db).CreateCompany(cpy); err != nil {
+		t.Fatal("CreateCompany failed:", err)
+	}
+
+	seekerID, err := userStore.CreateUser(&types.User{
+		Email:    "seeker@test.com",
+		Password: "Pass1234",
+		Role:     "JobSeeker",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+	js := &types.JobSeeker{FirstName: "fname", LastName: "lname", UserID: seekerID}
+	if err := jobseeker.NewJobseekerStore(db).CreateJobSeeker(js); err != nil {
+		t.Fatal("CreateJobSeeker failed:", err)
+	}
+
+	store := privacy.NewPrivacyStore(db)
+
+	assertVisible := func(want bool) {
+		t.Helper()
+		visible, err := store.IsVisibleToCompany(js.ID, cpy.ID)
+		if err != nil {
+			t.Fatal("IsVisibleToCompany failed:", err)
+		}
+		if visible != want {
+			t.Errorf("expected visible=%v, got %v", want, visible)
+		}
+	}
+
+	assertVisible(true)
+
+	block := &types.JobSeekerBlock{JobSeekerID: js.ID, EmailDomain: "acme.com"}
+	if err := store.CreateBlock(block); err != nil {
+		t.Fatal("CreateBlock failed:", err)
+	}
+	assertVisible(false)
+
+	dup := &types.JobSeekerBlock{JobSeekerID: js.ID, EmailDomain: "acme.com"}
+	if err := store.CreateBlock(dup); !errors.Is(err, privacy.ErrAlreadyBlocked) {
+		t.Errorf("expected ErrAlreadyBlocked, got %v", err)
+	}
+
+	if err := store.DeleteBlock(js.ID, block.ID); err != nil {
+		t.Fatal("DeleteBlock failed:", err)
+	}
+	assertVisible(true)
+
+	err = store.UpdatePrivacySettings(js.ID, &types.PrivacySettings{Visibility: "hidden", OpenToWork: true})
+	if err != nil {
+		t.Fatal("UpdatePrivacySettings failed:", err)
+	}
+	assertVisible(false)
+
+	settings, err := store.GetPrivacySettings(js.ID)
+	if err != nil {
+		t.Fatal("GetPrivacySettings failed:", err)
+	}
+	if settings.Visibility != "hidden" || !settings.OpenToWork {
+		t.Errorf("unexpected settings %+v", settings)
+	}
+}
@@ -0,0 +1,208 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const (
+	EventNotification = "notification"
+
+	// EventResync tells a client that events were lost while it was
+	// disconnected and that it should refetch state over the REST API.
+	EventResync = "resync"
+
+	defaultHistorySize      = 100
+	defaultSubscriberBuffer = 64
+
+	// defaultReplayWindow is how long a user's buffered events are kept
+	// after their last event or connection. A client that stays away longer
+	// is sent a resync instead of a replay.
+	defaultReplayWindow = 10 * time.Minute
+)
+
+type Event struct {
+	ID     uint64          `json:"id"`
+	UserID int             `json:"-"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Broker fans events out to the connections of a user. Hub is the in-process
+// implementation; a multi-instance deployment can swap in one backed by a
+// message broker.
+type Broker interface {
+	Publish(userID int, eventType string, data any) error
+	Subscribe(userID int, lastEventID uint64) *Subscription
+	Unsubscribe(sub *Subscription)
+}
+
+// Subscription delivers a user's events. Replay holds events published
+// after the Last-Event-ID the client reconnected with. Events is closed when
+// the subscriber falls too far behind, so a slow client never blocks
+// publishers; it is expected to reconnect and resume from its last event.
+type Subscription struct {
+	UserID int
+	Replay []Event
+	Events <-chan Event
+
+	events chan Event
+	closed bool
+}
+
+type Hub struct {
+	mu           sync.Mutex
+	startID      uint64
+	nextID       uint64
+	historySize  int
+	bufferSize   int
+	replayWindow time.Duration
+	now          func() time.Time
+	lastSweep    time.Time
+	history      map[int][]Event
+	evicted      map[int]uint64
+	lastActive   map[int]time.Time
+	subscribers  map[int]map[*Subscription]struct{}
+
+	// forgottenID is the newest event ID dropped along with an idle user's
+	// history. A client resuming from before it without buffered history
+	// may have missed events and is told to resync.
+	forgottenID uint64
+}
+
+func NewHub() *Hub {
+	// Seeding IDs from the clock keeps them increasing across restarts, so a
+	// Last-Event-ID from before a restart is never mistaken for a newer event.
+	startID := uint64(time.Now().UnixMilli()) * 1000
+
+	return &Hub{
+		startID:      startID,
+		nextID:       startID,
+		historySize:  defaultHistorySize,
+		bufferSize:   defaultSubscriberBuffer,
+		replayWindow: defaultReplayWindow,
+		now:          time.Now,
+		history:      make(map[int][]Event),
+		evicted:      make(map[int]uint64),
+		lastActive:   make(map[int]time.Time),
+		subscribers:  make(map[int]map[*Subscription]struct{}),
+	}
+}
+
+func (h *Hub) Publish(userID int, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	h.sweepLocked(now)
+	h.lastActive[userID] = now
+
+	h.nextID++
+	ev := Event{ID: h.nextID, UserID: userID, Type: eventType, Data: payload}
+
+	history := append(h.history[userID], ev)
+	if len(history) > h.historySize {
+		dropped := len(history) - h.historySize
+		h.evicted[userID] = history[dropped-1].ID
+		history = history[dropped:]
+	}
+	h.history[userID] = history
+
+	for sub := range h.subscribers[userID] {
+		select {
+		case sub.events <- ev:
+		default:
+			h.removeLocked(sub)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registers a new connection for userID. When lastEventID is set,
+// buffered events after it are returned in Replay, preceded by a resync event
+// if some of the missed events are no longer buffered or were published by a
+// previous process.
+func (h *Hub) Subscribe(userID int, lastEventID uint64) *Subscription {
+	events := make(chan Event, h.bufferSize)
+	sub := &Subscription{UserID: userID, Events: events, events: events}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if lastEventID > 0 {
+		forgotten := len(h.history[userID]) == 0 && lastEventID < h.forgottenID
+		if lastEventID < h.startID || lastEventID < h.evicted[userID] || forgotten {
+			h.nextID++
+			sub.Replay = append(sub.Replay, Event{
+				ID:     h.nextID,
+				UserID: userID,
+				Type:   EventResync,
+				Data:   json.RawMessage("{}"),
+			})
+		}
+		for _, ev := range h.history[userID] {
+			if ev.ID > lastEventID {
+				sub.Replay = append(sub.Replay, ev)
+			}
+		}
+	}
+
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(sub)
+}
+
+func (h *Hub) removeLocked(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.events)
+
+	subs := h.subscribers[sub.UserID]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.UserID)
+		h.lastActive[sub.UserID] = h.now()
+	}
+}
+
+// sweepLocked drops the buffered events of users who have had neither an
+// event nor a connection for the replay window, so the hub does not hold
+// on to every user it has ever seen. It runs at most once per window.
+func (h *Hub) sweepLocked(now time.Time) {
+	if now.Sub(h.lastSweep) < h.replayWindow {
+		return
+	}
+	h.lastSweep = now
+
+	for userID, active := range h.lastActive {
+		if len(h.subscribers[userID]) > 0 || now.Sub(active) < h.replayWindow {
+			continue
+		}
+
+		if history := h.history[userID]; len(history) > 0 {
+			h.forgottenID = max(h.forgottenID, history[len(history)-1].ID)
+		}
+		delete(h.history, userID)
+		delete(h.evicted, userID)
+		delete(h.lastActive, userID)
+	}
+}
@@ -0,0 +1,161 @@
+package realtime
+
+import (
+	"testing"
+	"time"
+)
+
+func receive(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+
+	select {
+	case ev, ok := <-sub.Events:
+		if !ok {
+			t.Fatal("expected an event, subscription was closed")
+		}
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return Event{}
+}
+
+func TestHub_PublishToSubscribedUser(t *testing.T) {
+	hub := NewHub()
+
+	alice := hub.Subscribe(1, 0)
+	bob := hub.Subscribe(2, 0)
+	defer hub.Unsubscribe(alice)
+	defer hub.Unsubscribe(bob)
+
+	if err := hub.Publish(1, EventNotification, map[string]string{"title": "hi"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ev := receive(t, alice)
+	if ev.Type != EventNotification || string(ev.Data) != `{"title":"hi"}` {
+		t.Errorf("unexpected event %+v", ev)
+	}
+
+	select {
+	case ev := <-bob.Events:
+		t.Errorf("expected no event for another user, got %+v", ev)
+	default:
+	}
+}
+
+func TestHub_ReplayAfterLastEventID(t *testing.T) {
+	hub := NewHub()
+
+	sub := hub.Subscribe(1, 0)
+	for i := 0; i < 3; i++ {
+		hub.Publish(1, EventNotification, i)
+	}
+	first := receive(t, sub)
+	hub.Unsubscribe(sub)
+
+	resumed := hub.Subscribe(1, first.ID)
+	defer hub.Unsubscribe(resumed)
+
+	if len(resumed.Replay) != 2 {
+		t.Fatalf("expected 2 replayed events, got %d", len(resumed.Replay))
+	}
+	if string(resumed.Replay[0].Data) != "1" || string(resumed.Replay[1].Data) != "2" {
+		t.Errorf("unexpected replay %+v", resumed.Replay)
+	}
+}
+
+func TestHub_ResyncWhenHistoryWasEvicted(t *testing.T) {
+	hub := NewHub()
+	hub.historySize = 2
+
+	hub.Publish(1, EventNotification, 0)
+	hub.Publish(1, EventNotification, 1)
+	hub.Publish(1, EventNotification, 2)
+	hub.Publish(1, EventNotification, 3)
+
+	// The client saw only the first event; the second has been evicted.
+	sub := hub.Subscribe(1, hub.startID+1)
+	defer hub.Unsubscribe(sub)
+
+	if len(sub.Replay) != 3 || sub.Replay[0].Type != EventResync {
+		t.Fatalf("expected a resync followed by 2 events, got %+v", sub.Replay)
+	}
+}
+
+func TestHub_ResyncAfterRestart(t *testing.T) {
+	hub := NewHub()
+
+	sub := hub.Subscribe(1, hub.startID-1)
+	defer hub.Unsubscribe(sub)
+
+	if len(sub.Replay) != 1 || sub.Replay[0].Type != EventResync {
+		t.Errorf("expected a single resync event, got %+v", sub.Replay)
+	}
+}
+
+func TestHub_DropsHistoryOfIdleUsers(t *testing.T) {
+	hub := NewHub()
+	now := time.Now()
+	hub.now = func() time.Time { return now }
+
+	hub.Publish(1, EventNotification, 0)
+	first := hub.nextID
+	hub.Publish(1, EventNotification, 1)
+
+	online := hub.Subscribe(2, 0)
+	defer hub.Unsubscribe(online)
+	hub.Publish(2, EventNotification, 0)
+
+	now = now.Add(hub.replayWindow)
+	hub.Publish(3, EventNotification, 0)
+
+	if _, ok := hub.history[1]; ok {
+		t.Error("expected the idle user's history to be dropped")
+	}
+	if _, ok := hub.history[2]; !ok {
+		t.Error("expected a connected user's history to be kept")
+	}
+
+	sub := hub.Subscribe(1, first)
+	defer hub.Unsubscribe(sub)
+
+	if len(sub.Replay) != 1 || sub.Replay[0].Type != EventResync {
+		t.Errorf("expected a single resync event, got %+v", sub.Replay)
+	}
+}
+
+func TestHub_SlowSubscriberDoesNotBlockPublisher(t *testing.T) {
+	hub := NewHub()
+	hub.bufferSize = 2
+
+	slow := hub.Subscribe(1, 0)
+	fast := hub.Subscribe(1, 0)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			hub.Publish(1, EventNotification, i)
+			// Keep the fast subscriber drained.
+			<-fast.Events
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publisher blocked on a slow subscriber")
+	}
+
+	drained := 0
+	for range slow.Events {
+		drained++
+	}
+	if drained != 2 {
+		t.Errorf("expected the slow subscriber to be closed after 2 buffered events, got %d", drained)
+	}
+
+	hub.Unsubscribe(fast)
+	hub.Unsubscribe(slow)
+}
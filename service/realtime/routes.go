@@ -0,0 +1,124 @@
+package realtime
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+	clientRetry       = 5 * time.Second
+)
+
+type Handler struct {
+	UserRepo          types.UserRepository
+	Broker            Broker
+	HeartbeatInterval time.Duration
+}
+
+func NewHandler(db *sql.DB, broker Broker) *Handler {
+	return &Handler{
+		UserRepo:          user.NewUserStore(db),
+		Broker:            broker,
+		HeartbeatInterval: heartbeatInterval,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/me/events", withQueryToken(auth.WithJWTAuth(h.handleEvents, h.UserRepo))).Methods("GET")
+}
+
+// withQueryToken lets EventSource clients, which cannot set headers,
+// authenticate with the token query parameter. It is only for the event
+// stream; every other route takes the token from the Authorization header.
+func withQueryToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("token"); token != "" && r.Header.Get("Authorization") == "" {
+			r = r.Clone(r.Context())
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		next(w, r)
+	}
+}
+
+// @Summary Stream live updates
+// @Description Server-Sent Events stream of the authenticated user's live updates. Browsers that cannot set headers on EventSource may pass the JWT in the token query parameter. Reconnecting clients resume from the Last-Event-ID header; a "resync" event means some updates were missed and state should be refetched.
+// @Tags realtime
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Param token query string false "JWT, for clients that cannot send the Authorization header"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/events [get]
+func (h *Handler) handleEvents(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("streaming unsupported"))
+		return
+	}
+
+	var lastEventID uint64
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		var err error
+		lastEventID, err = strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid Last-Event-ID"))
+			return
+		}
+	}
+
+	sub := h.Broker.Subscribe(userID, lastEventID)
+	defer h.Broker.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", clientRetry.Milliseconds())
+	for _, ev := range sub.Replay {
+		writeEvent(w, ev)
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev, ok := <-sub.Events:
+			if !ok {
+				// Dropped for falling behind; the client reconnects with its
+				// Last-Event-ID and catches up from the replay buffer.
+				return
+			}
+			writeEvent(w, ev)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, ev Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, ev.Data)
+}
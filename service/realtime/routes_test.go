@@ -0,0 +1,93 @@
+package realtime
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+)
+
+func TestHandleEvents(t *testing.T) {
+	hub := NewHub()
+	handler := &Handler{Broker: hub, HeartbeatInterval: 10 * time.Millisecond}
+
+	hub.Publish(1, EventNotification, "missed")
+	hub.Publish(1, EventNotification, "seen")
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), auth.UserKey, 1))
+	req := httptest.NewRequest("GET", "/me/events", nil).WithContext(ctx)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(hub.startID+1, 10))
+	rec := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		handler.handleEvents(rec, req)
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %s", ct)
+	}
+
+	body := rec.Body.String()
+	expected := "id: " + strconv.FormatUint(hub.startID+2, 10) + "\nevent: notification\ndata: \"seen\"\n\n"
+	if !strings.Contains(body, expected) {
+		t.Errorf("expected body to contain %q, got %q", expected, body)
+	}
+	if strings.Contains(body, "missed") {
+		t.Error("expected events up to Last-Event-ID not to be replayed")
+	}
+	if !strings.Contains(body, ": heartbeat\n\n") {
+		t.Error("expected a heartbeat comment")
+	}
+}
+
+func TestHandleEvents_InvalidLastEventID(t *testing.T) {
+	handler := &Handler{Broker: NewHub(), HeartbeatInterval: time.Second}
+
+	ctx := context.WithValue(context.Background(), auth.UserKey, 1)
+	req := httptest.NewRequest("GET", "/me/events", nil).WithContext(ctx)
+	req.Header.Set("Last-Event-ID", "not-a-number")
+	rec := httptest.NewRecorder()
+
+	handler.handleEvents(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d", rec.Code)
+	}
+}
+
+func TestWithQueryToken(t *testing.T) {
+	var got string
+	next := func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+	}
+
+	req := httptest.NewRequest("GET", "/me/events?token=abc", nil)
+	withQueryToken(next)(httptest.NewRecorder(), req)
+	if got != "Bearer abc" {
+		t.Errorf("expected the query token as bearer token, got %q", got)
+	}
+
+	req = httptest.NewRequest("GET", "/me/events?token=abc", nil)
+	req.Header.Set("Authorization", "Bearer header")
+	withQueryToken(next)(httptest.NewRecorder(), req)
+	if got != "Bearer header" {
+		t.Errorf("expected the Authorization header to win, got %q", got)
+	}
+}
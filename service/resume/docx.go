@@ -0,0 +1,81 @@
+package resume
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxDocumentSize bounds the uncompressed size of word/document.xml so a
+// small zip bomb cannot exhaust memory.
+const maxDocumentSize = 20 << 20
+
+// extractDOCXText reads the main document part of a DOCX file and returns its
+// text with one line per paragraph.
+func extractDOCXText(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open DOCX archive: %w", err)
+	}
+
+	var document *zip.File
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			document = f
+			break
+		}
+	}
+	if document == nil {
+		return "", fmt.Errorf("DOCX archive has no word/document.xml")
+	}
+	if document.UncompressedSize64 > maxDocumentSize {
+		return "", fmt.Errorf("DOCX document is larger than %d MB", maxDocumentSize>>20)
+	}
+
+	rc, err := document.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to read DOCX document: %w", err)
+	}
+	defer rc.Close()
+
+	var b strings.Builder
+	inText := false
+	decoder := xml.NewDecoder(io.LimitReader(rc, maxDocumentSize))
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse DOCX document: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				b.WriteByte('\t')
+			case "br", "cr":
+				b.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				b.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,39 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var ErrUnsupportedFormat = fmt.Errorf("unsupported resume format, expected PDF or DOCX")
+
+// ExtractText returns the plain text of a PDF or DOCX resume. The format is
+// detected from the file contents, with the file name only used to tell
+// DOCX apart from other zip archives.
+func ExtractText(filename string, data []byte) (string, error) {
+	var (
+		text string
+		err  error
+	)
+
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		text, err = extractPDFText(data)
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")) &&
+		strings.EqualFold(filepath.Ext(filename), ".docx"):
+		text, err = extractDOCXText(data)
+	default:
+		return "", ErrUnsupportedFormat
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("no extractable text found in resume")
+	}
+
+	return text, nil
+}
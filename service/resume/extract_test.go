@@ -0,0 +1,160 @@
+package resume_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/resume"
+)
+
+func buildDOCX(t *testing.T, documentXML string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	f, err := zw.Create("word/document.xml")
+	if err != nil {
+		t.Fatalf("failed to create document part: %v", err)
+	}
+	if _, err := f.Write([]byte(documentXML)); err != nil {
+		t.Fatalf("failed to write document part: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func buildPDF(t *testing.T, content string, compress bool) []byte {
+	t.Helper()
+
+	body := []byte(content)
+	filter := ""
+	if compress {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		zw.Write(body)
+		zw.Close()
+		body = buf.Bytes()
+		filter = " /Filter /FlateDecode"
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	pdf.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	fmt.Fprintf(&pdf, "4 0 obj\n<< /Length %d%s >>\nstream\n", len(body), filter)
+	pdf.Write(body)
+	pdf.WriteString("\nendstream\nendobj\n%%EOF\n")
+
+	return pdf.Bytes()
+}
+
+func TestExtractText_DOCX(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:r><w:t>Skills</w:t></w:r></w:p>
+<w:p><w:r><w:t>Go,</w:t></w:r><w:r><w:t xml:space="preserve"> Docker</w:t></w:r></w:p>
+</w:body>
+</w:document>`
+
+	text, err := resume.ExtractText("cv.docx", buildDOCX(t, doc))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if text != "Skills\nGo, Docker\n" {
+		t.Errorf("unexpected text %q", text)
+	}
+}
+
+func TestExtractText_PDF(t *testing.T) {
+	content := `BT
+/F1 12 Tf
+72 720 Td
+(Experience) Tj
+0 -14 Td
+[(Built services in )-50(Go)-300(and Kubernetes \(k8s\))] TJ
+T*
+<FEFF0044006F0063006B00650072> Tj
+ET`
+
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compressed=%v", compress), func(t *testing.T) {
+			text, err := resume.ExtractText("cv.pdf", buildPDF(t, content, compress))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			expected := "Experience\nBuilt services in Go and Kubernetes (k8s)\nDocker\n"
+			if !strings.HasSuffix(text, expected) {
+				t.Errorf("expected text to end with %q, got %q", expected, text)
+			}
+		})
+	}
+}
+
+func TestExtractText_UnsupportedFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+	}{
+		{"Plain text", "cv.txt", []byte("Jane Doe")},
+		{"Zip that is not DOCX", "cv.zip", buildDOCX(t, "<w:document/>")},
+		{"Empty file", "cv.pdf", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resume.ExtractText(tc.filename, tc.data)
+			if !errors.Is(err, resume.ErrUnsupportedFormat) {
+				t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+			}
+		})
+	}
+}
+
+func TestExtractText_NoText(t *testing.T) {
+	_, err := resume.ExtractText("cv.pdf", buildPDF(t, "0 0 m 10 10 l S", false))
+	if err == nil {
+		t.Fatal("expected an error for a PDF without text")
+	}
+}
+
+func TestExtractText_DeeplyNestedArrays(t *testing.T) {
+	content := "BT (Go) Tj " + strings.Repeat("[", 1<<20) + " ET"
+
+	text, err := resume.ExtractText("cv.pdf", buildPDF(t, content, true))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(text, "Go") {
+		t.Errorf("expected text before the nested arrays, got %q", text)
+	}
+}
+
+func TestExtractText_ManyStreams(t *testing.T) {
+	// Without a bound on the dictionary search every stream rescans the
+	// whole prefix, and this input takes minutes instead of milliseconds.
+	data := append([]byte("%PDF-1.4\n"), bytes.Repeat([]byte("stream\nBT (a) Tj ET\nendstream\n"), 200000)...)
+
+	if _, err := resume.ExtractText("cv.pdf", data); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestExtractText_DOCXTooLarge(t *testing.T) {
+	doc := "<w:document>" + strings.Repeat(" ", 21<<20) + "</w:document>"
+
+	if _, err := resume.ExtractText("cv.docx", buildDOCX(t, doc)); err == nil {
+		t.Fatal("expected an error for an oversized document")
+	}
+}
@@ -0,0 +1,362 @@
+package resume
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+)
+
+const (
+	maxProfileSummaryLength = 500
+	maxEducationLength      = 255
+	maxExperienceYears      = 50
+
+	// skillContextWindow is how close, in bytes, an ambiguous skill alias
+	// must be to another skill to be read as a skill outside the skills
+	// section.
+	skillContextWindow = 40
+)
+
+type section int
+
+const (
+	sectionNone section = iota
+	sectionSummary
+	sectionExperience
+	sectionEducation
+	sectionSkills
+	sectionOther
+)
+
+var sectionHeadings = map[string]section{
+	"summary":                   sectionSummary,
+	"profile":                   sectionSummary,
+	"profile summary":           sectionSummary,
+	"professional summary":      sectionSummary,
+	"about":                     sectionSummary,
+	"about me":                  sectionSummary,
+	"objective":                 sectionSummary,
+	"career objective":          sectionSummary,
+	"experience":                sectionExperience,
+	"work experience":           sectionExperience,
+	"professional experience":   sectionExperience,
+	"employment":                sectionExperience,
+	"employment history":        sectionExperience,
+	"work history":              sectionExperience,
+	"education":                 sectionEducation,
+	"education and training":    sectionEducation,
+	"academic background":       sectionEducation,
+	"qualifications":            sectionEducation,
+	"skills":                    sectionSkills,
+	"technical skills":          sectionSkills,
+	"key skills":                sectionSkills,
+	"core competencies":         sectionSkills,
+	"skills and competencies":   sectionSkills,
+	"projects":                  sectionOther,
+	"certifications":            sectionOther,
+	"languages":                 sectionOther,
+	"interests":                 sectionOther,
+	"hobbies":                   sectionOther,
+	"references":                sectionOther,
+	"awards":                    sectionOther,
+	"publications":              sectionOther,
+	"volunteering":              sectionOther,
+	"volunteer experience":      sectionOther,
+	"certifications and awards": sectionOther,
+}
+
+var degreeLevels = []struct {
+	level   int
+	pattern *regexp.Regexp
+}{
+	{4, regexp.MustCompile(`(?i)\b(ph\.?\s?d|doctorate|doctor of)\b`)},
+	{3, regexp.MustCompile(`(?i)\b(master|masters|msc|m\.sc|m\.s|mba|meng|m\.eng|ma in)\b`)},
+	{2, regexp.MustCompile(`(?i)\b(bachelor|bachelors|bsc|b\.sc|b\.s|ba in|beng|b\.eng|licence|license in)\b`)},
+	{1, regexp.MustCompile(`(?i)\b(associate degree|diploma|certificate in|high school)\b`)},
+}
+
+var (
+	statedExperienceRe = regexp.MustCompile(
+		`(?i)\b(\d{1,2})\+?\s*(?:years?|yrs?)\b(?:\s+of)?(?:\s+[a-z\-/]+){0,3}?\s+experience\b`,
+	)
+	monthPattern = `(jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*\.?`
+	dateRangeRe  = regexp.MustCompile(
+		`(?i)(?:\b` + monthPattern + `\s+)?\b((?:19|20)\d{2})\s*(?:-|–|—|to|until)\s*(?:` +
+			monthPattern + `\s+)?(?:\b((?:19|20)\d{2})\b|\b(present|current|now|today)\b)`,
+	)
+)
+
+var monthNumbers = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// Parse turns plain resume text into a draft of profile fields, recognising
+// the skills in terms. now is used to resolve open-ended date ranges such as
+// "2021 - Present".
+func Parse(text string, terms []types.SkillTerm, now time.Time) *types.ResumeDraft {
+	sections := splitSections(text)
+
+	return &types.ResumeDraft{
+		ProfileSummary: parseProfileSummary(sections),
+		Skills:         parseSkills(text, sections[sectionSkills], terms),
+		Experience:     parseExperience(text, sections[sectionExperience], now),
+		Education:      parseEducation(text, sections[sectionEducation]),
+	}
+}
+
+// splitSections groups the lines of text under the most recent recognised
+// heading. Lines before the first heading are kept under sectionNone.
+func splitSections(text string) map[section][]string {
+	sections := make(map[section][]string)
+	current := sectionNone
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		heading := strings.ToLower(strings.TrimRight(line, ": "))
+		if s, ok := sectionHeadings[heading]; ok {
+			current = s
+			continue
+		}
+
+		sections[current] = append(sections[current], line)
+	}
+
+	return sections
+}
+
+func parseProfileSummary(sections map[section][]string) string {
+	summary := collapseSpaces(strings.Join(sections[sectionSummary], " "))
+	return truncateAtWord(summary, maxProfileSummaryLength)
+}
+
+// parseSkills returns the names of catalogue skills found in the text,
+// ordered by first appearance. Ambiguous aliases only count inside the skills
+// section or within skillContextWindow bytes of another skill, so "excel at
+// teamwork" is not read as a skill while "Excel and SQL" is.
+func parseSkills(text string, skillsSection []string, terms []types.SkillTerm) []string {
+	fullText := strings.ToLower(text)
+	sectionText := strings.ToLower(strings.Join(skillsSection, "\n"))
+
+	positions := make(map[string]int)
+	record := func(name string, pos int) {
+		if prev, ok := positions[name]; !ok || pos < prev {
+			positions[name] = pos
+		}
+	}
+
+	var anchors []int
+	for _, term := range terms {
+		if term.Ambiguous {
+			continue
+		}
+		for _, pos := range indexTerms(fullText, term.Alias) {
+			anchors = append(anchors, pos)
+			record(term.Name, pos)
+		}
+	}
+
+	for _, term := range terms {
+		if !term.Ambiguous {
+			continue
+		}
+		for _, pos := range indexTerms(fullText, term.Alias) {
+			if nearAnchor(anchors, pos) {
+				record(term.Name, pos)
+				break
+			}
+		}
+		if pos := indexTerm(sectionText, term.Alias, 0); pos >= 0 {
+			// Rank section-only matches after everything found in the body.
+			record(term.Name, pos+len(fullText))
+		}
+	}
+
+	skills := make([]string, 0, len(positions))
+	for name := range positions {
+		skills = append(skills, name)
+	}
+	sort.SliceStable(skills, func(i, j int) bool {
+		if positions[skills[i]] != positions[skills[j]] {
+			return positions[skills[i]] < positions[skills[j]]
+		}
+		return skills[i] < skills[j]
+	})
+
+	return skills
+}
+
+func nearAnchor(anchors []int, pos int) bool {
+	for _, a := range anchors {
+		if a != pos && a >= pos-skillContextWindow && a <= pos+skillContextWindow {
+			return true
+		}
+	}
+	return false
+}
+
+// indexTerms returns the offsets of every whole-word occurrence of term.
+func indexTerms(text, term string) []int {
+	var found []int
+	for offset := 0; offset < len(text); {
+		i := indexTerm(text, term, offset)
+		if i < 0 {
+			break
+		}
+		found = append(found, i)
+		offset = i + 1
+	}
+	return found
+}
+
+// indexTerm finds term in text as a whole word, starting at offset.
+// Characters that commonly extend a skill name ("+", "#") count as part of
+// the word.
+func indexTerm(text, term string, offset int) int {
+	for {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			return -1
+		}
+		start := offset + i
+		end := start + len(term)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if start == 0 {
+			before = ' '
+		}
+		if end == len(text) {
+			after = ' '
+		}
+
+		matched := !isWordRune(before) && !isWordRune(after)
+		if matched && after == '.' && isWordContinuation(text[end:]) {
+			matched = false
+		}
+		if matched && before == '.' && start >= 2 {
+			prev, _ := utf8.DecodeLastRuneInString(text[:start-1])
+			matched = !unicode.IsLetter(prev) && !unicode.IsDigit(prev)
+		}
+		if matched {
+			return start
+		}
+
+		offset = start + 1
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#'
+}
+
+// isWordContinuation reports whether a "." following a term starts another
+// word segment, as in "node.js", rather than ending a sentence. The same rule
+// is applied backwards so "js" does not match inside "node.js".
+func isWordContinuation(rest string) bool {
+	if len(rest) < 2 {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(rest[1:])
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// parseExperience estimates years of experience. It takes the larger of any
+// explicitly stated figure and the total covered by date ranges in the
+// experience section, counting overlapping positions once.
+func parseExperience(text string, experienceSection []string, now time.Time) int {
+	years := 0
+	for _, m := range statedExperienceRe.FindAllStringSubmatch(text, -1) {
+		if n, err := strconv.Atoi(m[1]); err == nil && n > years {
+			years = n
+		}
+	}
+
+	var ranges []utils.MonthRange
+	nowMonth := utils.MonthNumber(now)
+
+	for _, line := range experienceSection {
+		for _, m := range dateRangeRe.FindAllStringSubmatch(line, -1) {
+			startYear, _ := strconv.Atoi(m[2])
+			start := startYear*12 + monthIndex(m[1], 0)
+
+			end := nowMonth
+			if m[4] != "" {
+				endYear, _ := strconv.Atoi(m[4])
+				end = min(endYear*12+monthIndex(m[3], 11), nowMonth)
+			}
+
+			ranges = append(ranges, utils.MonthRange{Start: start, End: end})
+		}
+	}
+
+	months := utils.CoveredMonths(ranges)
+	if months/12 > years {
+		years = months / 12
+	}
+	if years > maxExperienceYears {
+		years = maxExperienceYears
+	}
+
+	return years
+}
+
+func monthIndex(name string, fallback int) int {
+	if len(name) < 3 {
+		return fallback
+	}
+	if n, ok := monthNumbers[strings.ToLower(name[:3])]; ok {
+		return n - 1
+	}
+	return fallback
+}
+
+// parseEducation returns the line describing the highest degree, preferring
+// the education section when the resume has one.
+func parseEducation(text string, educationSection []string) string {
+	lines := educationSection
+	if len(lines) == 0 {
+		lines = strings.Split(text, "\n")
+	}
+
+	best, bestLevel := "", 0
+	for _, line := range lines {
+		for _, d := range degreeLevels {
+			if d.level > bestLevel && d.pattern.MatchString(line) {
+				best, bestLevel = line, d.level
+				break
+			}
+		}
+	}
+
+	return truncateAtWord(collapseSpaces(best), maxEducationLength)
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncateAtWord(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	runes := []rune(s)[:limit]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+
+	return cut
+}
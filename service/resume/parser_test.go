@@ -0,0 +1,149 @@
+package resume_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/resume"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+var now = time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+// terms mirrors part of the seeded skill catalogue.
+var terms = []types.SkillTerm{
+	{Name: "Go", Alias: "go", Ambiguous: true},
+	{Name: "Go", Alias: "golang"},
+	{Name: "Python", Alias: "python"},
+	{Name: "Java", Alias: "java"},
+	{Name: "JavaScript", Alias: "javascript"},
+	{Name: "JavaScript", Alias: "js"},
+	{Name: "C", Alias: "c", Ambiguous: true},
+	{Name: "C++", Alias: "c++"},
+	{Name: "C#", Alias: "c#"},
+	{Name: "SQL", Alias: "sql"},
+	{Name: "Node.js", Alias: "node.js"},
+	{Name: "Node.js", Alias: "node", Ambiguous: true},
+	{Name: "PostgreSQL", Alias: "postgresql"},
+	{Name: "Docker", Alias: "docker"},
+	{Name: "Kubernetes", Alias: "kubernetes"},
+	{Name: "AWS", Alias: "aws"},
+	{Name: "Git", Alias: "git"},
+	{Name: "Excel", Alias: "excel", Ambiguous: true},
+}
+
+const sampleResume = `Jane Doe
+jane.doe@example.com
+
+Summary
+Backend engineer focused on distributed systems and developer tooling.
+I enjoy building reliable APIs in Golang.
+
+Experience
+Senior Engineer, Acme Corp    Jan 2020 - Present
+Built services with Docker and Kubernetes on AWS.
+Engineer, Globex    Jun 2016 - Dec 2019
+Maintained a Node.js and PostgreSQL platform.
+
+Education
+BSc Computer Science, University of Somewhere    2012 - 2016
+MSc Software Engineering, University of Elsewhere    2016 - 2017
+
+Skills
+Go, Python, SQL, Git, C++, c#
+`
+
+func TestParse(t *testing.T) {
+	draft := resume.Parse(sampleResume, terms, now)
+
+	t.Run("Profile summary", func(t *testing.T) {
+		expected := "Backend engineer focused on distributed systems and developer tooling. I enjoy building reliable APIs in Golang."
+		if draft.ProfileSummary != expected {
+			t.Errorf("expected summary %q, got %q", expected, draft.ProfileSummary)
+		}
+	})
+
+	t.Run("Skills", func(t *testing.T) {
+		expected := []string{
+			"Go", "Docker", "Kubernetes", "AWS", "Node.js", "PostgreSQL",
+			"Python", "SQL", "Git", "C++", "C#",
+		}
+		if !reflect.DeepEqual(draft.Skills, expected) {
+			t.Errorf("expected skills %v, got %v", expected, draft.Skills)
+		}
+	})
+
+	t.Run("Experience", func(t *testing.T) {
+		// Jun 2016 to Mar 2025 without gaps is 8 years and 10 months.
+		if draft.Experience != 8 {
+			t.Errorf("expected 8 years of experience, got %d", draft.Experience)
+		}
+	})
+
+	t.Run("Education", func(t *testing.T) {
+		expected := "MSc Software Engineering, University of Elsewhere 2016 - 2017"
+		if draft.Education != expected {
+			t.Errorf("expected education %q, got %q", expected, draft.Education)
+		}
+	})
+}
+
+func TestParse_StatedExperience(t *testing.T) {
+	draft := resume.Parse("Data analyst with 12+ years of professional experience in Excel.", terms, now)
+
+	if draft.Experience != 12 {
+		t.Errorf("expected 12 years of experience, got %d", draft.Experience)
+	}
+}
+
+func TestParse_AmbiguousSkillsOnlyInSkillsSection(t *testing.T) {
+	text := "Summary\nReady to go the extra mile and learn C along the way.\n"
+
+	draft := resume.Parse(text, terms, now)
+	if len(draft.Skills) != 0 {
+		t.Errorf("expected no skills, got %v", draft.Skills)
+	}
+}
+
+func TestParse_DoesNotMatchPartialWords(t *testing.T) {
+	draft := resume.Parse("Skilled in JavaScript and scripting; ran a Java-free shop.", terms, now)
+
+	expected := []string{"JavaScript", "Java"}
+	if !reflect.DeepEqual(draft.Skills, expected) {
+		t.Errorf("expected skills %v, got %v", expected, draft.Skills)
+	}
+}
+
+func TestParse_TruncatesLongSummary(t *testing.T) {
+	text := "Summary\n" + strings.Repeat("word ", 200)
+
+	draft := resume.Parse(text, terms, now)
+	if len(draft.ProfileSummary) > 500 {
+		t.Errorf("expected summary of at most 500 characters, got %d", len(draft.ProfileSummary))
+	}
+	if strings.HasSuffix(draft.ProfileSummary, " ") {
+		t.Error("expected summary to be cut at a word boundary")
+	}
+}
+
+func TestParse_AmbiguousSkillsNeedTechnicalContext(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"I excel at teamwork and was a key node of the team.", []string{}},
+		{"Built reporting in Excel and SQL.", []string{"Excel", "SQL"}},
+		{"Built APIs with Node and PostgreSQL.", []string{"Node.js", "PostgreSQL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			draft := resume.Parse(tt.text, terms, now)
+			if !reflect.DeepEqual(draft.Skills, tt.expected) {
+				t.Errorf("expected skills %v, got %v", tt.expected, draft.Skills)
+			}
+		})
+	}
+}
@@ -0,0 +1,465 @@
+package resume
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	maxDecodedStreamSize = 10 << 20
+	// maxDecodedPDFSize bounds the decoded size of all streams together, so
+	// many small compressed streams cannot inflate without limit.
+	maxDecodedPDFSize = 20 << 20
+	// maxArrayDepth bounds array nesting in content streams, which are
+	// parsed recursively.
+	maxArrayDepth = 32
+	// maxDictSize bounds how far back from a stream its dictionary is
+	// searched for.
+	maxDictSize = 4 << 10
+)
+
+var (
+	pdfStreamKeyword    = []byte("stream")
+	pdfEndStreamKeyword = []byte("endstream")
+	pdfObjKeyword       = []byte("obj")
+
+	// Streams carrying these entries hold fonts, images or cross-reference
+	// data rather than page content.
+	pdfSkippedStreamKeys = [][]byte{
+		[]byte("/Image"),
+		[]byte("/XRef"),
+		[]byte("/ObjStm"),
+		[]byte("/Length1"),
+		[]byte("/FontFile"),
+		[]byte("/Metadata"),
+	}
+)
+
+// extractPDFText pulls text out of the content streams of a PDF. It handles
+// uncompressed and FlateDecode streams with simple font encodings, which
+// covers resumes exported by common word processors. Scanned documents and
+// fonts with custom glyph mappings yield little or no text.
+func extractPDFText(data []byte) (string, error) {
+	var b strings.Builder
+
+	budget := maxDecodedPDFSize
+	offset := 0
+	// prevEnd is where the previous stream ended. A stream's dictionary
+	// follows it, so the backward search never rescans earlier streams.
+	prevEnd := 0
+	for budget > 0 {
+		i := bytes.Index(data[offset:], pdfStreamKeyword)
+		if i < 0 {
+			break
+		}
+		start := offset + i
+		offset = start + len(pdfStreamKeyword)
+
+		// Skip the "stream" inside "endstream".
+		if start >= 3 && bytes.Equal(data[start-3:start], []byte("end")) {
+			continue
+		}
+
+		searchFrom := max(prevEnd, start-maxDictSize)
+		dictStart := bytes.LastIndex(data[searchFrom:start], pdfObjKeyword)
+		if dictStart < 0 {
+			dictStart = 0
+		}
+		dict := data[searchFrom+dictStart : start]
+
+		bodyStart := offset
+		if bodyStart < len(data) && data[bodyStart] == '\r' {
+			bodyStart++
+		}
+		if bodyStart < len(data) && data[bodyStart] == '\n' {
+			bodyStart++
+		}
+
+		end := bytes.Index(data[bodyStart:], pdfEndStreamKeyword)
+		if end < 0 {
+			break
+		}
+		body := data[bodyStart : bodyStart+end]
+		offset = bodyStart + end + len(pdfEndStreamKeyword)
+		prevEnd = offset
+
+		if skipPDFStream(dict) {
+			continue
+		}
+
+		if bytes.Contains(dict, []byte("/FlateDecode")) {
+			decoded, err := inflate(body, min(budget, maxDecodedStreamSize))
+			if err != nil {
+				continue
+			}
+			body = decoded
+			budget -= len(body)
+		} else if bytes.Contains(dict, []byte("/Filter")) {
+			// Other filters (DCT, LZW, ...) are not used for text content.
+			continue
+		}
+
+		b.WriteString(extractContentStreamText(body))
+	}
+
+	if b.Len() == 0 {
+		return "", fmt.Errorf("no extractable text found in PDF")
+	}
+
+	return b.String(), nil
+}
+
+func skipPDFStream(dict []byte) bool {
+	for _, key := range pdfSkippedStreamKeys {
+		if bytes.Contains(dict, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func inflate(body []byte, limit int) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	out, err := io.ReadAll(io.LimitReader(zr, int64(limit)))
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// extractContentStreamText interprets the text-showing operators of a page
+// content stream (Tj, TJ, ' and ") and turns line moves into newlines.
+func extractContentStreamText(content []byte) string {
+	var (
+		b        strings.Builder
+		operands []any
+		inText   bool
+		lastY    float64
+	)
+
+	newline := func() {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteByte('\n')
+		}
+	}
+
+	p := &contentParser{data: content}
+	for {
+		tok, ok := p.next()
+		if !ok {
+			break
+		}
+
+		op, isOperator := tok.(pdfOperator)
+		if !isOperator {
+			operands = append(operands, tok)
+			continue
+		}
+
+		switch op {
+		case "BT":
+			inText = true
+		case "ET":
+			inText = false
+			newline()
+		case "Tj":
+			if inText && len(operands) > 0 {
+				if s, ok := operands[len(operands)-1].(string); ok {
+					b.WriteString(s)
+				}
+			}
+		case "'", "\"":
+			newline()
+			if inText && len(operands) > 0 {
+				if s, ok := operands[len(operands)-1].(string); ok {
+					b.WriteString(s)
+				}
+			}
+		case "TJ":
+			if inText && len(operands) > 0 {
+				if arr, ok := operands[len(operands)-1].([]any); ok {
+					for _, el := range arr {
+						switch v := el.(type) {
+						case string:
+							b.WriteString(v)
+						case float64:
+							// Large negative adjustments separate words.
+							if v < -200 {
+								b.WriteByte(' ')
+							}
+						}
+					}
+				}
+			}
+		case "T*":
+			newline()
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty, ok := operands[len(operands)-1].(float64); ok && ty != 0 {
+					newline()
+				} else {
+					b.WriteByte(' ')
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				if y, ok := operands[len(operands)-1].(float64); ok {
+					if y != lastY {
+						newline()
+					}
+					lastY = y
+				}
+			}
+		}
+
+		operands = operands[:0]
+	}
+
+	newline()
+	return b.String()
+}
+
+type pdfOperator string
+
+type contentParser struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// next returns the next token of a content stream: a float64, a decoded
+// string, an array of tokens, a name (as pdfName) or an operator.
+func (p *contentParser) next() (any, bool) {
+	p.skipWhitespace()
+	if p.pos >= len(p.data) {
+		return nil, false
+	}
+
+	c := p.data[p.pos]
+	switch {
+	case c == '(':
+		return p.literalString(), true
+	case c == '<' && p.peek(1) == '<':
+		p.pos += 2
+		return pdfOperator("<<"), true
+	case c == '>' && p.peek(1) == '>':
+		p.pos += 2
+		return pdfOperator(">>"), true
+	case c == '<':
+		return p.hexString(), true
+	case c == '[' && p.depth >= maxArrayDepth:
+		// Too deeply nested to be text; skip the bracket without recursing.
+		p.pos++
+		return pdfOperator("["), true
+	case c == '[':
+		p.pos++
+		p.depth++
+		defer func() { p.depth-- }()
+
+		var arr []any
+		for {
+			p.skipWhitespace()
+			if p.pos >= len(p.data) {
+				return arr, true
+			}
+			if p.data[p.pos] == ']' {
+				p.pos++
+				return arr, true
+			}
+			tok, ok := p.next()
+			if !ok {
+				return arr, true
+			}
+			arr = append(arr, tok)
+		}
+	case c == '/':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.data) && !isPDFDelimiter(p.data[p.pos]) {
+			p.pos++
+		}
+		return pdfName(p.data[start:p.pos]), true
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.data) && !isPDFDelimiter(p.data[p.pos]) {
+			p.pos++
+		}
+		f, err := strconv.ParseFloat(string(p.data[start:p.pos]), 64)
+		if err != nil {
+			return pdfOperator(p.data[start:p.pos]), true
+		}
+		return f, true
+	case isPDFDelimiter(c):
+		p.pos++
+		return pdfOperator(string(c)), true
+	default:
+		start := p.pos
+		for p.pos < len(p.data) && !isPDFDelimiter(p.data[p.pos]) {
+			p.pos++
+		}
+		return pdfOperator(p.data[start:p.pos]), true
+	}
+}
+
+type pdfName string
+
+func (p *contentParser) peek(n int) byte {
+	if p.pos+n < len(p.data) {
+		return p.data[p.pos+n]
+	}
+	return 0
+}
+
+func (p *contentParser) skipWhitespace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n', '\f', 0:
+			p.pos++
+		case '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *contentParser) literalString() string {
+	var out []byte
+	depth := 0
+	p.pos++ // opening parenthesis
+
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+
+		switch c {
+		case '(':
+			depth++
+			out = append(out, c)
+		case ')':
+			if depth == 0 {
+				return decodePDFString(out)
+			}
+			depth--
+			out = append(out, c)
+		case '\\':
+			if p.pos >= len(p.data) {
+				break
+			}
+			e := p.data[p.pos]
+			p.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+					p.pos++
+				}
+			case '\n':
+				// Line continuation.
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for k := 0; k < 2 && p.pos < len(p.data); k++ {
+						d := p.data[p.pos]
+						if d < '0' || d > '7' {
+							break
+						}
+						v = v*8 + int(d-'0')
+						p.pos++
+					}
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return decodePDFString(out)
+}
+
+func (p *contentParser) hexString() string {
+	p.pos++ // opening angle bracket
+
+	var digits []byte
+	for p.pos < len(p.data) && p.data[p.pos] != '>' {
+		c := p.data[p.pos]
+		if isHexDigit(c) {
+			digits = append(digits, c)
+		}
+		p.pos++
+	}
+	p.pos++ // closing angle bracket
+
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	out := make([]byte, len(digits)/2)
+	for i := range out {
+		v, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		out[i] = byte(v)
+	}
+
+	return decodePDFString(out)
+}
+
+// decodePDFString converts raw string bytes to UTF-8. Strings starting with a
+// UTF-16BE byte order mark are decoded as such, anything else is treated as
+// Latin-1, which matches PDFDocEncoding for printable characters.
+func decodePDFString(raw []byte) string {
+	if len(raw) >= 2 && raw[0] == 0xFE && raw[1] == 0xFF {
+		units := make([]uint16, 0, (len(raw)-2)/2)
+		for i := 2; i+1 < len(raw); i += 2 {
+			units = append(units, uint16(raw[i])<<8|uint16(raw[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+
+	runes := make([]rune, 0, len(raw))
+	for _, c := range raw {
+		if c < 0x20 && c != '\n' && c != '\t' {
+			continue
+		}
+		runes = append(runes, rune(c))
+	}
+
+	return string(runes)
+}
+
+func isPDFDelimiter(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '\f', 0, '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,97 @@
+package resume
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/skill"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+const maxResumeSize = 5 << 20
+
+type Handler struct {
+	UserRepo  types.UserRepository
+	SkillRepo types.SkillRepository
+}
+
+func NewHandler(db *sql.DB) *Handler {
+	return &Handler{
+		UserRepo:  user.NewUserStore(db),
+		SkillRepo: skill.NewSkillStore(db),
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc(
+		"/me/resume/parse",
+		auth.WithJWTAuth(h.handleParseResume, h.UserRepo),
+	).Methods("POST")
+}
+
+// @Summary Parse a resume
+// @Description Extract text from an uploaded PDF or DOCX resume and return suggested profile fields. The profile itself is left unchanged.
+// @Tags jobseeker
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param resume formData file true "Resume file (PDF or DOCX, up to 5 MB)"
+// @Success 200 {object} types.ResumeDraft "Suggested profile fields"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 415 {object} map[string]string "Unsupported Media Type"
+// @Failure 422 {object} map[string]string "Unprocessable Entity"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me/resume/parse [post]
+func (h *Handler) handleParseResume(w http.ResponseWriter, r *http.Request) {
+	if !strings.EqualFold(auth.GetUserRoleFromContext(r.Context()), "JobSeeker") {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("only job seekers can parse resumes"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxResumeSize)
+	if err := r.ParseMultipartForm(maxResumeSize); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid upload: %v", err))
+		return
+	}
+
+	file, header, err := r.FormFile("resume")
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("missing resume file"))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	text, err := ExtractText(header.Filename, data)
+	if errors.Is(err, ErrUnsupportedFormat) {
+		utils.WriteError(w, http.StatusUnsupportedMediaType, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	terms, err := h.SkillRepo.GetSkillTerms()
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, Parse(text, terms, time.Now().UTC()))
+}
@@ -0,0 +1,420 @@
+package review
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+const (
+	EventReviewModerated = "review.moderated"
+	EventReviewPublished = "review.published"
+	EventReviewReplied   = "review.replied"
+
+	maxTitleNameLength = 200
+)
+
+type Handler struct {
+	ReviewRepo  types.CompanyReviewRepository
+	CompanyRepo types.CompanyRepository
+	MemberRepo  types.CompanyMemberRepository
+	UserRepo    types.UserRepository
+	Notifier    types.Notifier
+}
+
+func NewHandler(db *sql.DB, notifier types.Notifier) *Handler {
+	return &Handler{
+		ReviewRepo:  NewReviewStore(db),
+		CompanyRepo: company.NewCompany(db),
+		MemberRepo:  company.NewMemberStore(db),
+		UserRepo:    user.NewUserStore(db),
+		Notifier:    notifier,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/reviews",
+		h.handleGetCompanyReviews,
+	).Methods("GET")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/reviews/summary",
+		h.handleGetRatingSummary,
+	).Methods("GET")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/reviews",
+		auth.WithJWTAuth(h.handleCreateReview, h.UserRepo),
+	).Methods("POST")
+	router.HandleFunc(
+		"/companies/{companyID:[0-9]+}/reviews/{reviewID:[0-9]+}/reply",
+		auth.WithJWTAuth(h.handleReplyToReview, h.UserRepo),
+	).Methods("POST")
+	router.HandleFunc(
+		"/admin/reviews",
+		auth.WithJWTAuth(h.handleGetModerationQueue, h.UserRepo),
+	).Methods("GET")
+	router.HandleFunc(
+		"/admin/reviews/{reviewID:[0-9]+}/moderate",
+		auth.WithJWTAuth(h.handleModerateReview, h.UserRepo),
+	).Methods("POST")
+}
+
+// @Summary Review a company
+// @Description Submit a review of a company. Reviews are published after moderation, and each user may review a company once.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Param review body types.CreateCompanyReviewRequest true "Review"
+// @Success 201 {object} types.CompanyReview "Review submitted for moderation"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/reviews [post]
+func (h *Handler) handleCreateReview(w http.ResponseWriter, r *http.Request) {
+	if !strings.EqualFold(auth.GetUserRoleFromContext(r.Context()), "JobSeeker") {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("only job seekers can review companies"))
+		return
+	}
+
+	cpy, ok := h.companyFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req types.CreateCompanyReviewRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	rev := &types.CompanyReview{
+		CompanyID:        cpy.ID,
+		UserID:           auth.GetUserIDFromContext(r.Context()),
+		OverallRating:    req.OverallRating,
+		CultureRating:    req.CultureRating,
+		PayRating:        req.PayRating,
+		ManagementRating: req.ManagementRating,
+		Pros:             req.Pros,
+		Cons:             req.Cons,
+		IsAnonymous:      req.IsAnonymous,
+	}
+
+	err := h.ReviewRepo.CreateReview(rev)
+	if errors.Is(err, ErrAlreadyReviewed) {
+		utils.WriteError(w, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, rev)
+}
+
+// @Summary List company reviews
+// @Description List approved reviews of a company, newest first.
+// @Tags reviews
+// @Produce json
+// @Param companyID path int true "Company ID"
+// @Param page query int false "Page number (default 1)"
+// @Param pageSize query int false "Page size (default 20, max 100)"
+// @Success 200 {array} types.PublicCompanyReview "Reviews"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/reviews [get]
+func (h *Handler) handleGetCompanyReviews(w http.ResponseWriter, r *http.Request) {
+	cpy, ok := h.companyFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset, err := utils.ParsePagination(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	reviews, err := h.ReviewRepo.GetPublicReviewsByCompanyID(cpy.ID, limit, offset)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, reviews)
+}
+
+// @Summary Company rating summary
+// @Description Aggregate ratings over a company's approved reviews.
+// @Tags reviews
+// @Produce json
+// @Param companyID path int true "Company ID"
+// @Success 200 {object} types.CompanyRatingSummary "Rating summary"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/reviews/summary [get]
+func (h *Handler) handleGetRatingSummary(w http.ResponseWriter, r *http.Request) {
+	cpy, ok := h.companyFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	summary, err := h.ReviewRepo.GetRatingSummary(cpy.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, summary)
+}
+
+// @Summary Reply to a review
+// @Description Post the company's public reply to an approved review. Requires the owner or admin role on the company team. Each review accepts one reply.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param companyID path int true "Company ID"
+// @Param reviewID path int true "Review ID"
+// @Param reply body types.ReviewReplyRequest true "Reply"
+// @Success 200 {object} types.SuccessResponse "Reply posted"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/companies/{companyID}/reviews/{reviewID}/reply [post]
+func (h *Handler) handleReplyToReview(w http.ResponseWriter, r *http.Request) {
+	cpy, ok := h.companyFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	m, err := h.MemberRepo.GetMember(cpy.ID, auth.GetUserIDFromContext(r.Context()))
+	if err != nil || !company.HasRole(m, company.RoleAdmin) {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("only company owners and admins can reply to reviews"))
+		return
+	}
+
+	rev, ok := h.reviewFromPath(w, r)
+	if !ok {
+		return
+	}
+	if rev.CompanyID != cpy.ID || rev.Status != "Approved" {
+		utils.WriteError(w, http.StatusNotFound, fmt.Errorf("review not found"))
+		return
+	}
+
+	var req types.ReviewReplyRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	err = h.ReviewRepo.SetReviewReply(rev.ID, req.Reply)
+	if errors.Is(err, ErrAlreadyReplied) {
+		utils.WriteError(w, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.Notifier.NotifyAsync(&types.Notification{
+		UserID: rev.UserID,
+		Type:   EventReviewReplied,
+		Title:  fmt.Sprintf("%s replied to your review", titleName(cpy.Name)),
+	})
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Reply posted"})
+}
+
+// @Summary Review moderation queue
+// @Description List reviews with the given status, oldest first. Admins only.
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Pending (default), Approved or Rejected"
+// @Param page query int false "Page number (default 1)"
+// @Param pageSize query int false "Page size (default 20, max 100)"
+// @Success 200 {array} types.CompanyReview "Reviews"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/admin/reviews [get]
+func (h *Handler) handleGetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	if !strings.EqualFold(auth.GetUserRoleFromContext(r.Context()), "Admin") {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("permission denied"))
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = "Pending"
+	}
+	if err := utils.Validate.Var(status, "oneof=Pending Approved Rejected"); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid status"))
+		return
+	}
+
+	limit, offset, err := utils.ParsePagination(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	reviews, err := h.ReviewRepo.GetReviewsByStatus(status, limit, offset)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, reviews)
+}
+
+// @Summary Moderate a review
+// @Description Approve or reject a review. Approved reviews appear on the company page, and the company's owners and admins are notified. Admins only.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param reviewID path int true "Review ID"
+// @Param decision body types.ModerateReviewRequest true "Moderation decision"
+// @Success 200 {object} types.SuccessResponse "Review moderated"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/admin/reviews/{reviewID}/moderate [post]
+func (h *Handler) handleModerateReview(w http.ResponseWriter, r *http.Request) {
+	if !strings.EqualFold(auth.GetUserRoleFromContext(r.Context()), "Admin") {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("permission denied"))
+		return
+	}
+
+	rev, ok := h.reviewFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req types.ModerateReviewRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	cpy, err := h.CompanyRepo.GetCompanyByID(rev.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	published := req.Status == "Approved" && rev.Status != "Approved"
+
+	var members []types.CompanyMember
+	if published {
+		members, err = h.MemberRepo.GetMembers(cpy.ID)
+		if err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	moderatorID := auth.GetUserIDFromContext(r.Context())
+	if err := h.ReviewRepo.ModerateReview(rev.ID, req.Status, req.Note, moderatorID); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.Notifier.NotifyAsync(&types.Notification{
+		UserID: rev.UserID,
+		Type:   EventReviewModerated,
+		Title:  fmt.Sprintf("Your review of %s was %s", titleName(cpy.Name), strings.ToLower(req.Status)),
+		Body:   req.Note,
+	})
+	for _, m := range members {
+		if !company.RoleAtLeast(m.Role, company.RoleAdmin) {
+			continue
+		}
+		h.Notifier.NotifyAsync(&types.Notification{
+			UserID: m.UserID,
+			Type:   EventReviewPublished,
+			Title:  fmt.Sprintf("A new review of %s was published", titleName(cpy.Name)),
+		})
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Review moderated"})
+}
+
+// titleName shortens a company name so notification titles built around it
+// stay within the 255 characters of the title column.
+func titleName(name string) string {
+	if utf8.RuneCountInString(name) <= maxTitleNameLength {
+		return name
+	}
+	return string([]rune(name)[:maxTitleNameLength-1]) + "…"
+}
+
+func (h *Handler) companyFromPath(w http.ResponseWriter, r *http.Request) (*types.Company, bool) {
+	companyID, err := strconv.Atoi(mux.Vars(r)["companyID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid company id"))
+		return nil, false
+	}
+
+	cpy, err := h.CompanyRepo.GetCompanyByID(companyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+
+	return cpy, true
+}
+
+func (h *Handler) reviewFromPath(w http.ResponseWriter, r *http.Request) (*types.CompanyReview, bool) {
+	reviewID, err := strconv.Atoi(mux.Vars(r)["reviewID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid review id"))
+		return nil, false
+	}
+
+	rev, err := h.ReviewRepo.GetReviewByID(reviewID)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+
+	return rev, true
+}
@@ -0,0 +1,292 @@
+package review
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/go-sql-driver/mysql"
+)
+
+const mysqlErrDuplicateEntry = 1062
+
+var (
+	ErrAlreadyReviewed = errors.New("you have already reviewed this company")
+	ErrAlreadyReplied  = errors.New("this review already has a reply")
+)
+
+type reviewStore struct {
+	db *sql.DB
+}
+
+func NewReviewStore(db *sql.DB) types.CompanyReviewRepository {
+	return &reviewStore{
+		db: db,
+	}
+}
+
+func (s *reviewStore) CreateReview(rev *types.CompanyReview) error {
+	res, err := s.db.Exec(
+		`INSERT INTO CompanyReview
+		(companyID, userID, overallRating, cultureRating, payRating, managementRating, pros, cons, isAnonymous)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rev.CompanyID,
+		rev.UserID,
+		rev.OverallRating,
+		rev.CultureRating,
+		rev.PayRating,
+		rev.ManagementRating,
+		rev.Pros,
+		rev.Cons,
+		rev.IsAnonymous,
+	)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return ErrAlreadyReviewed
+	}
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	rev.ID = int(id)
+	rev.Status = "Pending"
+
+	return nil
+}
+
+func (s *reviewStore) GetReviewByID(id int) (*types.CompanyReview, error) {
+	rows, err := s.db.Query(selectReview+" WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rev := new(types.CompanyReview)
+	for rows.Next() {
+		rev, err = scanRowsIntoReview(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rev.ID == 0 {
+		return nil, fmt.Errorf("review not found")
+	}
+
+	return rev, nil
+}
+
+// GetPublicReviewsByCompanyID lists approved reviews, newest first. The
+// author is shown by first name and last initial unless they chose to stay
+// anonymous.
+func (s *reviewStore) GetPublicReviewsByCompanyID(
+	companyID int,
+	limit, offset int,
+) ([]types.PublicCompanyReview, error) {
+	rows, err := s.db.Query(
+		`SELECT r.id, r.companyID,
+			CASE WHEN r.isAnonymous OR js.id IS NULL THEN 'Anonymous'
+				ELSE CONCAT(js.firstName, ' ', LEFT(js.lastName, 1), '.') END,
+			r.overallRating, r.cultureRating, r.payRating, r.managementRating,
+			COALESCE(r.pros, ''), COALESCE(r.cons, ''), COALESCE(r.reply, ''), r.repliedAt, r.createdAt
+		FROM CompanyReview r
+		LEFT JOIN JobSeeker js ON js.userID = r.userID
+		WHERE r.companyID = ? AND r.status = 'Approved'
+		ORDER BY r.createdAt DESC, r.id DESC
+		LIMIT ? OFFSET ?`,
+		companyID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]types.PublicCompanyReview, 0)
+	for rows.Next() {
+		var (
+			rev       types.PublicCompanyReview
+			repliedAt sql.NullTime
+		)
+		err := rows.Scan(
+			&rev.ID,
+			&rev.CompanyID,
+			&rev.AuthorName,
+			&rev.OverallRating,
+			&rev.CultureRating,
+			&rev.PayRating,
+			&rev.ManagementRating,
+			&rev.Pros,
+			&rev.Cons,
+			&rev.Reply,
+			&repliedAt,
+			&rev.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if repliedAt.Valid {
+			rev.RepliedAt = &repliedAt.Time
+		}
+		reviews = append(reviews, rev)
+	}
+
+	return reviews, rows.Err()
+}
+
+// GetReviewsByStatus returns the moderation queue for a status, oldest first.
+func (s *reviewStore) GetReviewsByStatus(status string, limit, offset int) ([]types.CompanyReview, error) {
+	rows, err := s.db.Query(
+		selectReview+" WHERE status = ? ORDER BY createdAt, id LIMIT ? OFFSET ?",
+		status,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]types.CompanyReview, 0)
+	for rows.Next() {
+		rev, err := scanRowsIntoReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, *rev)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (s *reviewStore) ModerateReview(id int, status string, note string, moderatorID int) error {
+	res, err := s.db.Exec(
+		`UPDATE CompanyReview
+		SET status = ?, moderationNote = ?, moderatedBy = ?, moderatedAt = UTC_TIMESTAMP()
+		WHERE id = ?`,
+		status,
+		note,
+		moderatorID,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("review not found")
+	}
+
+	return nil
+}
+
+// SetReviewReply stores the company's public reply. Each review accepts a
+// single reply.
+func (s *reviewStore) SetReviewReply(id int, reply string) error {
+	res, err := s.db.Exec(
+		"UPDATE CompanyReview SET reply = ?, repliedAt = UTC_TIMESTAMP() WHERE id = ? AND reply IS NULL",
+		reply,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrAlreadyReplied
+	}
+
+	return nil
+}
+
+func (s *reviewStore) GetRatingSummary(companyID int) (*types.CompanyRatingSummary, error) {
+	summary := &types.CompanyRatingSummary{
+		CompanyID:    companyID,
+		Distribution: map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0},
+	}
+
+	err := s.db.QueryRow(
+		`SELECT COUNT(*),
+			COALESCE(ROUND(AVG(overallRating), 2), 0),
+			COALESCE(ROUND(AVG(cultureRating), 2), 0),
+			COALESCE(ROUND(AVG(payRating), 2), 0),
+			COALESCE(ROUND(AVG(managementRating), 2), 0)
+		FROM CompanyReview
+		WHERE companyID = ? AND status = 'Approved'`,
+		companyID,
+	).Scan(
+		&summary.ReviewCount,
+		&summary.AverageOverall,
+		&summary.AverageCulture,
+		&summary.AveragePay,
+		&summary.AverageManagement,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(
+		`SELECT overallRating, COUNT(*)
+		FROM CompanyReview
+		WHERE companyID = ? AND status = 'Approved'
+		GROUP BY overallRating`,
+		companyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rating, count int
+		if err := rows.Scan(&rating, &count); err != nil {
+			return nil, err
+		}
+		summary.Distribution[rating] = count
+	}
+
+	return summary, rows.Err()
+}
+
+const selectReview = `SELECT id, companyID, userID, overallRating, cultureRating, payRating,
+	managementRating, COALESCE(pros, ''), COALESCE(cons, ''), isAnonymous, status,
+	COALESCE(moderationNote, ''), COALESCE(reply, ''), repliedAt, createdAt
+	FROM CompanyReview`
+
+func scanRowsIntoReview(rows *sql.Rows) (*types.CompanyReview, error) {
+	rev := new(types.CompanyReview)
+
+	var repliedAt sql.NullTime
+	err := rows.Scan(
+		&rev.ID,
+		&rev.CompanyID,
+		&rev.UserID,
+		&rev.OverallRating,
+		&rev.CultureRating,
+		&rev.PayRating,
+		&rev.ManagementRating,
+		&rev.Pros,
+		&rev.Cons,
+		&rev.IsAnonymous,
+		&rev.Status,
+		&rev.ModerationNote,
+		&rev.Reply,
+		&repliedAt,
+		&rev.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if repliedAt.Valid {
+		rev.RepliedAt = &repliedAt.Time
+	}
+
+	return rev, nil
+}
@@ -0,0 +1,104 @@
+package review_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/service/review"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/testutils"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+func TestReviewStore_ModerationAndSummary(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userStore := user.NewUserStore(db)
+
+	ownerID, err := userStore.CreateUser(&types.User{
+		Email:    "owner@test.com",
+		Password: "Pass1234",
+		Role:     "Company",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+
+	cpy := &types.Company{Name: "Acme", UserID: ownerID}
+	if err := company.NewCompany(db).CreateCompany(cpy); err != nil {
+		t.Fatal("CreateCompany failed:", err)
+	}
+
+	store := review.NewReviewStore(db)
+
+	var reviews []*types.CompanyReview
+	for i, rating := range []int{5, 3} {
+		authorID, err := userStore.CreateUser(&types.User{
+			Email:    []string{"a@test.com", "b@test.com"}[i],
+			Password: "Pass1234",
+			Role:     "JobSeeker",
+		})
+		if err != nil {
+			t.Fatal("CreateUser failed:", err)
+		}
+
+		rev := &types.CompanyReview{
+			CompanyID:        cpy.ID,
+			UserID:           authorID,
+			OverallRating:    rating,
+			CultureRating:    rating,
+			PayRating:        rating,
+			ManagementRating: rating,
+			IsAnonymous:      true,
+		}
+		if err := store.CreateReview(rev); err != nil {
+			t.Fatal("CreateReview failed:", err)
+		}
+		reviews = append(reviews, rev)
+	}
+
+	dup := *reviews[0]
+	if err := store.CreateReview(&dup); !errors.Is(err, review.ErrAlreadyReviewed) {
+		t.Errorf("expected ErrAlreadyReviewed, got %v", err)
+	}
+
+	public, err := store.GetPublicReviewsByCompanyID(cpy.ID, 10, 0)
+	if err != nil {
+		t.Fatal("GetPublicReviewsByCompanyID failed:", err)
+	}
+	if len(public) != 0 {
+		t.Errorf("expected pending reviews to be hidden, got %d", len(public))
+	}
+
+	if err := store.ModerateReview(reviews[0].ID, "Approved", "", ownerID); err != nil {
+		t.Fatal("ModerateReview failed:", err)
+	}
+	if err := store.ModerateReview(reviews[1].ID, "Rejected", "off-topic", ownerID); err != nil {
+		t.Fatal("ModerateReview failed:", err)
+	}
+
+	public, err = store.GetPublicReviewsByCompanyID(cpy.ID, 10, 0)
+	if err != nil {
+		t.Fatal("GetPublicReviewsByCompanyID failed:", err)
+	}
+	if len(public) != 1 || public[0].AuthorName != "Anonymous" {
+		t.Errorf("expected one anonymous approved review, got %+v", public)
+	}
+
+	summary, err := store.GetRatingSummary(cpy.ID)
+	if err != nil {
+		t.Fatal("GetRatingSummary failed:", err)
+	}
+	if summary.ReviewCount != 1 || summary.AverageOverall != 5 || summary.Distribution[5] != 1 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	if err := store.SetReviewReply(reviews[0].ID, "Thanks!"); err != nil {
+		t.Fatal("SetReviewReply failed:", err)
+	}
+	if err := store.SetReviewReply(reviews[0].ID, "Again"); !errors.Is(err, review.ErrAlreadyReplied) {
+		t.Errorf("expected ErrAlreadyReplied, got %v", err)
+	}
+}
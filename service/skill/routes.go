@@ -0,0 +1,73 @@
+package skill
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
+type Handler struct {
+	SkillRepo types.SkillRepository
+}
+
+func NewHandler(db *sql.DB) *Handler {
+	return &Handler{
+		SkillRepo: NewSkillStore(db),
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/skills", h.handleSearchSkills).Methods("GET")
+}
+
+// @Summary Autocomplete skills
+// @Description Search the skills catalogue by name or alias prefix. Only curated skills are suggested; skills added from free-form profile input are left out.
+// @Tags skills
+// @Produce json
+// @Param q query string true "Name or alias prefix"
+// @Param category query string false "Only return skills in this category"
+// @Param limit query int false "Maximum number of results (default 10, max 50)"
+// @Success 200 {array} types.Skill "Matching skills"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/skills [get]
+func (h *Handler) handleSearchSkills(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("missing query parameter q"))
+		return
+	}
+
+	limit := defaultSearchLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > maxSearchLimit {
+			utils.WriteError(
+				w,
+				http.StatusBadRequest,
+				fmt.Errorf("limit must be between 1 and %d", maxSearchLimit),
+			)
+			return
+		}
+		limit = n
+	}
+
+	skills, err := h.SkillRepo.SearchSkills(query, r.URL.Query().Get("category"), limit)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, skills)
+}
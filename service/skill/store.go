@@ -0,0 +1,249 @@
+package skill
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+)
+
+// Querier is satisfied by both *sql.DB and *sql.Tx so skills can be resolved
+// inside the transaction that stores the profile using them.
+type Querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+type skillStore struct {
+	db *sql.DB
+}
+
+func NewSkillStore(db *sql.DB) types.SkillRepository {
+	return &skillStore{
+		db: db,
+	}
+}
+
+// SearchSkills returns catalogue entries whose name or one of whose aliases
+// starts with query. Name matches are listed before alias matches. Skills
+// added from free-form profile input have no category and are not suggested,
+// so one user's typo does not show up in everyone's autocomplete.
+func (s *skillStore) SearchSkills(query string, category string, limit int) ([]types.Skill, error) {
+	prefix := utils.EscapeLike(NormalizeName(query)) + "%"
+
+	rows, err := s.db.Query(
+		`SELECT s.id, s.name, COALESCE(s.category, '')
+		FROM Skill s
+		WHERE s.category IS NOT NULL
+		AND (s.name LIKE ? OR EXISTS (
+			SELECT 1 FROM SkillAlias a WHERE a.skillID = s.id AND a.alias LIKE ?
+		))
+		AND (? = '' OR s.category = ?)
+		ORDER BY s.name LIKE ? DESC, s.name
+		LIMIT ?`,
+		prefix,
+		prefix,
+		category,
+		category,
+		prefix,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRowsIntoSkills(rows)
+}
+
+func (s *skillStore) GetSkillsByJobSeekerID(jobSeekerID int) ([]types.Skill, error) {
+	rows, err := s.db.Query(
+		`SELECT s.id, s.name, COALESCE(s.category, '')
+		FROM JobSeekerSkill jss
+		JOIN Skill s ON s.id = jss.skillID
+		WHERE jss.jobSeekerID = ?
+		ORDER BY s.name`,
+		jobSeekerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRowsIntoSkills(rows)
+}
+
+// GetSkillTerms returns every alias of the curated catalogue, so text can be
+// matched against the same skills that autocomplete suggests.
+func (s *skillStore) GetSkillTerms() ([]types.SkillTerm, error) {
+	rows, err := s.db.Query(
+		`SELECT s.name, a.alias, a.ambiguous
+		FROM SkillAlias a
+		JOIN Skill s ON s.id = a.skillID
+		WHERE s.category IS NOT NULL
+		ORDER BY s.id, a.alias`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	terms := make([]types.SkillTerm, 0)
+	for rows.Next() {
+		var t types.SkillTerm
+		if err := rows.Scan(&t.Name, &t.Alias, &t.Ambiguous); err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+	}
+
+	return terms, rows.Err()
+}
+
+// ResolveSkills maps free-form skill names to catalogue entries, so "JS",
+// "javascript" and "JavaScript " all resolve to the same skill. Names that are
+// not in the catalogue yet are added to it. The result keeps the order of
+// names and contains each skill once.
+func ResolveSkills(q Querier, names []string) ([]types.Skill, error) {
+	var skills []types.Skill
+	seen := make(map[int]bool)
+
+	for _, name := range names {
+		name = NormalizeName(name)
+		if name == "" {
+			continue
+		}
+		if utf8.RuneCountInString(name) > 100 {
+			return nil, fmt.Errorf("skill %q is longer than 100 characters", name)
+		}
+
+		sk, err := resolveSkill(q, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[sk.ID] {
+			seen[sk.ID] = true
+			skills = append(skills, *sk)
+		}
+	}
+
+	return skills, nil
+}
+
+// LookupSkillIDs maps skill names to catalogue IDs through their aliases
+// without adding anything to the catalogue. Names that are not in the
+// catalogue are returned in unknown.
+func LookupSkillIDs(q Querier, names []string) (ids []int, unknown []string, err error) {
+	seen := make(map[int]bool)
+
+	for _, name := range names {
+		name = NormalizeName(name)
+		if name == "" {
+			continue
+		}
+
+		var id int
+		err := q.QueryRow(
+			"SELECT skillID FROM SkillAlias WHERE alias = ?",
+			strings.ToLower(name),
+		).Scan(&id)
+		if err == sql.ErrNoRows {
+			unknown = append(unknown, name)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, unknown, nil
+}
+
+func resolveSkill(q Querier, name string) (*types.Skill, error) {
+	sk := new(types.Skill)
+
+	err := q.QueryRow(
+		`SELECT s.id, s.name, COALESCE(s.category, '')
+		FROM SkillAlias a
+		JOIN Skill s ON s.id = a.skillID
+		WHERE a.alias = ?`,
+		strings.ToLower(name),
+	).Scan(&sk.ID, &sk.Name, &sk.Category)
+	if err == nil {
+		return sk, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	// LAST_INSERT_ID(id) makes a concurrent insert of the same name return the
+	// existing row instead of failing on the unique key.
+	res, err := q.Exec(
+		"INSERT INTO Skill (name) VALUES (?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)",
+		name,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = q.Exec(
+		"INSERT IGNORE INTO SkillAlias (alias, skillID) VALUES (?, ?)",
+		strings.ToLower(name),
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.QueryRow(
+		"SELECT id, name, COALESCE(category, '') FROM Skill WHERE id = ?",
+		id,
+	).Scan(&sk.ID, &sk.Name, &sk.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	return sk, nil
+}
+
+// NormalizeName trims a skill name and collapses inner whitespace.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Names returns the names of skills in order.
+func Names(skills []types.Skill) []string {
+	names := make([]string, 0, len(skills))
+	for _, sk := range skills {
+		names = append(names, sk.Name)
+	}
+	return names
+}
+
+func scanRowsIntoSkills(rows *sql.Rows) ([]types.Skill, error) {
+	skills := make([]types.Skill, 0)
+	for rows.Next() {
+		var sk types.Skill
+		if err := rows.Scan(&sk.ID, &sk.Name, &sk.Category); err != nil {
+			return nil, err
+		}
+		skills = append(skills, sk)
+	}
+
+	return skills, rows.Err()
+}
@@ -0,0 +1,159 @@
+package skill_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/skill"
+	"github.com/AyKrimino/JobSeekerAPI/testutils"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+func TestResolveSkills_NormalizesAliases(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	skills, err := skill.ResolveSkills(db, []string{"JS", "javascript", "JavaScript ", "  golang"})
+	if err != nil {
+		t.Fatal("ResolveSkills failed:", err)
+	}
+
+	if len(skills) != 2 {
+		t.Fatalf("expected 2 skills, got %d: %v", len(skills), skills)
+	}
+	if skills[0].Name != "JavaScript" {
+		t.Errorf("expected JavaScript, got %s", skills[0].Name)
+	}
+	if skills[1].Name != "Go" {
+		t.Errorf("expected Go, got %s", skills[1].Name)
+	}
+}
+
+func TestResolveSkills_CreatesUnknownSkill(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	first, err := skill.ResolveSkills(db, []string{"Underwater  Basket Weaving"})
+	if err != nil {
+		t.Fatal("ResolveSkills failed:", err)
+	}
+
+	second, err := skill.ResolveSkills(db, []string{"underwater basket weaving"})
+	if err != nil {
+		t.Fatal("ResolveSkills failed:", err)
+	}
+
+	if len(first) != 1 || len(second) != 1 {
+		t.Fatalf("expected one skill each time, got %v and %v", first, second)
+	}
+	if first[0].ID != second[0].ID {
+		t.Errorf("expected the same skill, got IDs %d and %d", first[0].ID, second[0].ID)
+	}
+	if first[0].Name != "Underwater Basket Weaving" {
+		t.Errorf("expected collapsed whitespace, got %q", first[0].Name)
+	}
+}
+
+func TestResolveSkills_CountsCharacters(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	name := strings.Repeat("é", 100)
+	skills, err := skill.ResolveSkills(db, []string{name})
+	if err != nil {
+		t.Fatal("ResolveSkills failed:", err)
+	}
+	if len(skills) != 1 || skills[0].Name != name {
+		t.Errorf("expected a 100-character skill to be accepted, got %v", skills)
+	}
+
+	if _, err := skill.ResolveSkills(db, []string{name + "é"}); err == nil {
+		t.Error("expected a 101-character skill to be rejected")
+	}
+}
+
+func TestGetSkillTerms(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	if _, err := skill.ResolveSkills(db, []string{"Underwater Basket Weaving"}); err != nil {
+		t.Fatal("ResolveSkills failed:", err)
+	}
+
+	terms, err := skill.NewSkillStore(db).GetSkillTerms()
+	if err != nil {
+		t.Fatal("GetSkillTerms failed:", err)
+	}
+
+	found := make(map[string]types.SkillTerm)
+	for _, term := range terms {
+		found[term.Alias] = term
+	}
+
+	if term, ok := found["golang"]; !ok || term.Name != "Go" || term.Ambiguous {
+		t.Errorf("expected golang as an unambiguous alias of Go, got %+v", term)
+	}
+	if term, ok := found["excel"]; !ok || !term.Ambiguous {
+		t.Errorf("expected excel to be ambiguous, got %+v", term)
+	}
+	if _, ok := found["underwater basket weaving"]; ok {
+		t.Error("expected user-created skills to be left out")
+	}
+}
+
+func TestSearchSkills(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	skillStore := skill.NewSkillStore(db)
+
+	t.Run("Matches alias", func(t *testing.T) {
+		skills, err := skillStore.SearchSkills("k8", "", 10)
+		if err != nil {
+			t.Fatal("SearchSkills failed:", err)
+		}
+
+		if len(skills) != 1 || skills[0].Name != "Kubernetes" {
+			t.Errorf("expected Kubernetes, got %v", skills)
+		}
+	})
+
+	t.Run("Filters by category", func(t *testing.T) {
+		skills, err := skillStore.SearchSkills("p", "Database", 10)
+		if err != nil {
+			t.Fatal("SearchSkills failed:", err)
+		}
+
+		for _, sk := range skills {
+			if sk.Category != "Database" {
+				t.Errorf("expected only Database skills, got %v", sk)
+			}
+		}
+	})
+
+	t.Run("Leaves out user-created skills", func(t *testing.T) {
+		if _, err := skill.ResolveSkills(db, []string{"Kubernetes Whispering"}); err != nil {
+			t.Fatal("ResolveSkills failed:", err)
+		}
+
+		skills, err := skillStore.SearchSkills("kubernetes", "", 10)
+		if err != nil {
+			t.Fatal("SearchSkills failed:", err)
+		}
+
+		if len(skills) != 1 || skills[0].Name != "Kubernetes" {
+			t.Errorf("expected only Kubernetes, got %v", skills)
+		}
+	})
+
+	t.Run("Escapes wildcards", func(t *testing.T) {
+		skills, err := skillStore.SearchSkills("%", "", 10)
+		if err != nil {
+			t.Fatal("SearchSkills failed:", err)
+		}
+
+		if len(skills) != 0 {
+			t.Errorf("expected no skills, got %v", skills)
+		}
+	})
+}
@@ -0,0 +1,575 @@
+package talent
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) registerPoolRoutes(router *mux.Router) {
+	router.HandleFunc("/talent/pools", auth.WithJWTAuth(h.handleGetPools, h.UserRepo)).Methods("GET")
+	router.HandleFunc("/talent/pools", auth.WithJWTAuth(h.handleCreatePool, h.UserRepo)).Methods("POST")
+	router.HandleFunc(
+		"/talent/pools/{poolID:[0-9]+}",
+		auth.WithJWTAuth(h.handleRenamePool, h.UserRepo),
+	).Methods("PATCH")
+	router.HandleFunc(
+		"/talent/pools/{poolID:[0-9]+}",
+		auth.WithJWTAuth(h.handleDeletePool, h.UserRepo),
+	).Methods("DELETE")
+	router.HandleFunc(
+		"/talent/pools/{poolID:[0-9]+}/candidates",
+		auth.WithJWTAuth(h.handleGetPoolCandidates, h.UserRepo),
+	).Methods("GET")
+	router.HandleFunc(
+		"/talent/pools/{poolID:[0-9]+}/candidates",
+		auth.WithJWTAuth(h.handleAddCandidate, h.UserRepo),
+	).Methods("POST")
+	router.HandleFunc(
+		"/talent/pools/{poolID:[0-9]+}/candidates/{jobSeekerID:[0-9]+}",
+		auth.WithJWTAuth(h.handleRemoveCandidate, h.UserRepo),
+	).Methods("DELETE")
+	router.HandleFunc(
+		"/talent/candidates/{jobSeekerID:[0-9]+}/tags",
+		auth.WithJWTAuth(h.handleGetTags, h.UserRepo),
+	).Methods("GET")
+	router.HandleFunc(
+		"/talent/candidates/{jobSeekerID:[0-9]+}/tags",
+		auth.WithJWTAuth(h.handleSetTags, h.UserRepo),
+	).Methods("PUT")
+	router.HandleFunc(
+		"/talent/candidates/{jobSeekerID:[0-9]+}/notes",
+		auth.WithJWTAuth(h.handleGetNotes, h.UserRepo),
+	).Methods("GET")
+	router.HandleFunc(
+		"/talent/candidates/{jobSeekerID:[0-9]+}/notes",
+		auth.WithJWTAuth(h.handleCreateNote, h.UserRepo),
+	).Methods("POST")
+}
+
+// @Summary List talent pools
+// @Description List the talent pools of the caller's company with their candidate counts. Any team member may view them.
+// @Tags talent
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.TalentPool "Pools"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/pools [get]
+func (h *Handler) handleGetPools(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, company.RoleViewer)
+	if !ok {
+		return
+	}
+
+	pools, err := h.PoolRepo.GetPools(actor.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, pools)
+}
+
+// @Summary Create a talent pool
+// @Description Create a named pool shared with the company team. Requires the recruiter role or higher.
+// @Tags talent
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param pool body types.TalentPoolRequest true "Pool name"
+// @Success 201 {object} types.TalentPool "Pool created"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/pools [post]
+func (h *Handler) handleCreatePool(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, company.RoleRecruiter)
+	if !ok {
+		return
+	}
+
+	req, ok := parsePoolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	p := &types.TalentPool{CompanyID: actor.CompanyID, Name: req.Name, CreatedBy: actor.UserID}
+	err := h.PoolRepo.CreatePool(p)
+	if errors.Is(err, ErrPoolNameTaken) {
+		utils.WriteError(w, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	p, err = h.PoolRepo.GetPool(actor.CompanyID, p.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, p)
+}
+
+// @Summary Rename a talent pool
+// @Description Requires the recruiter role or higher.
+// @Tags talent
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param poolID path int true "Pool ID"
+// @Param pool body types.TalentPoolRequest true "New name"
+// @Success 200 {object} types.TalentPool "Renamed pool"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/pools/{poolID} [patch]
+func (h *Handler) handleRenamePool(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, company.RoleRecruiter)
+	if !ok {
+		return
+	}
+
+	p, ok := h.poolFromPath(w, r, actor)
+	if !ok {
+		return
+	}
+
+	req, ok := parsePoolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.PoolRepo.RenamePool(actor.CompanyID, p.ID, req.Name)
+	if errors.Is(err, ErrPoolNameTaken) {
+		utils.WriteError(w, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	p.Name = req.Name
+
+	utils.WriteJSON(w, http.StatusOK, p)
+}
+
+// @Summary Delete a talent pool
+// @Description Remove a pool and its memberships. Tags and notes on its candidates are kept. Requires the recruiter role or higher.
+// @Tags talent
+// @Produce json
+// @Security BearerAuth
+// @Param poolID path int true "Pool ID"
+// @Success 200 {object} types.SuccessResponse "Pool deleted"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/pools/{poolID} [delete]
+func (h *Handler) handleDeletePool(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, company.RoleRecruiter)
+	if !ok {
+		return
+	}
+
+	poolID, err := strconv.Atoi(mux.Vars(r)["poolID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid pool id"))
+		return
+	}
+
+	err = h.PoolRepo.DeletePool(actor.CompanyID, poolID)
+	if errors.Is(err, ErrPoolNotFound) {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Pool deleted"})
+}
+
+// @Summary List pool candidates
+// @Description List a pool's candidates with their tags, most recently added first. Candidates who have since hidden their profile or blocked the company are left out. Any team member may view them.
+// @Tags talent
+// @Produce json
+// @Security BearerAuth
+// @Param poolID path int true "Pool ID"
+// @Param tag query string false "Only candidates with this tag"
+// @Param page query int false "Page number (default 1)"
+// @Param pageSize query int false "Page size (default 20, max 100)"
+// @Success 200 {array} types.PoolCandidate "Candidates"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/pools/{poolID}/candidates [get]
+func (h *Handler) handleGetPoolCandidates(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, company.RoleViewer)
+	if !ok {
+		return
+	}
+
+	p, ok := h.poolFromPath(w, r, actor)
+	if !ok {
+		return
+	}
+
+	limit, offset, err := utils.ParsePagination(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	tag := normalizeTag(r.URL.Query().Get("tag"))
+	candidates, err := h.PoolRepo.GetPoolCandidates(p, tag, limit, offset)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, candidates)
+}
+
+// @Summary Add a candidate to a pool
+// @Description Requires the recruiter role or higher. Only job seekers visible to the company can be added.
+// @Tags talent
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param poolID path int true "Pool ID"
+// @Param candidate body types.AddCandidateRequest true "Job seeker to add"
+// @Success 201 {object} types.SuccessResponse "Candidate added"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 409 {object} map[string]string "Conflict"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/pools/{poolID}/candidates [post]
+func (h *Handler) handleAddCandidate(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, company.RoleRecruiter)
+	if !ok {
+		return
+	}
+
+	p, ok := h.poolFromPath(w, r, actor)
+	if !ok {
+		return
+	}
+
+	var req types.AddCandidateRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	if !h.checkCandidateVisible(w, req.JobSeekerID, actor) {
+		return
+	}
+
+	err := h.PoolRepo.AddCandidate(p.ID, req.JobSeekerID, actor.UserID)
+	if errors.Is(err, ErrAlreadyInPool) {
+		utils.WriteError(w, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, types.SuccessResponse{Message: "Candidate added"})
+}
+
+// @Summary Remove a candidate from a pool
+// @Description Requires the recruiter role or higher. The candidate's tags and notes are kept.
+// @Tags talent
+// @Produce json
+// @Security BearerAuth
+// @Param poolID path int true "Pool ID"
+// @Param jobSeekerID path int true "Job seeker ID"
+// @Success 200 {object} types.SuccessResponse "Candidate removed"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/pools/{poolID}/candidates/{jobSeekerID} [delete]
+func (h *Handler) handleRemoveCandidate(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.memberFromContext(w, r, company.RoleRecruiter)
+	if !ok {
+		return
+	}
+
+	p, ok := h.poolFromPath(w, r, actor)
+	if !ok {
+		return
+	}
+
+	jobSeekerID, err := strconv.Atoi(mux.Vars(r)["jobSeekerID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid job seeker id"))
+		return
+	}
+
+	err = h.PoolRepo.RemoveCandidate(p.ID, jobSeekerID)
+	if errors.Is(err, ErrNotInPool) {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, types.SuccessResponse{Message: "Candidate removed"})
+}
+
+// @Summary Get candidate tags
+// @Description Tags the caller's company gave the job seeker. Any team member may view them.
+// @Tags talent
+// @Produce json
+// @Security BearerAuth
+// @Param jobSeekerID path int true "Job seeker ID"
+// @Success 200 {array} string "Tags"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/candidates/{jobSeekerID}/tags [get]
+func (h *Handler) handleGetTags(w http.ResponseWriter, r *http.Request) {
+	actor, jobSeekerID, ok := h.candidateFromPath(w, r, company.RoleViewer)
+	if !ok {
+		return
+	}
+
+	tags, err := h.PoolRepo.GetTags(actor.CompanyID, jobSeekerID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, tags)
+}
+
+// @Summary Set candidate tags
+// @Description Replace the tags the caller's company gave the job seeker. Tags are lowercased. Requires the recruiter role or higher.
+// @Tags talent
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param jobSeekerID path int true "Job seeker ID"
+// @Param tags body types.SetTagsRequest true "Tags"
+// @Success 200 {array} string "Tags"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/candidates/{jobSeekerID}/tags [put]
+func (h *Handler) handleSetTags(w http.ResponseWriter, r *http.Request) {
+	actor, jobSeekerID, ok := h.candidateFromPath(w, r, company.RoleRecruiter)
+	if !ok {
+		return
+	}
+
+	var req types.SetTagsRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	for i, tag := range req.Tags {
+		req.Tags[i] = normalizeTag(tag)
+	}
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	if err := h.PoolRepo.SetTags(actor.CompanyID, jobSeekerID, req.Tags); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	tags, err := h.PoolRepo.GetTags(actor.CompanyID, jobSeekerID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, tags)
+}
+
+// @Summary List candidate notes
+// @Description Private notes the caller's company wrote about the job seeker, newest first. Any team member may view them.
+// @Tags talent
+// @Produce json
+// @Security BearerAuth
+// @Param jobSeekerID path int true "Job seeker ID"
+// @Success 200 {array} types.CandidateNote "Notes"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/candidates/{jobSeekerID}/notes [get]
+func (h *Handler) handleGetNotes(w http.ResponseWriter, r *http.Request) {
+	actor, jobSeekerID, ok := h.candidateFromPath(w, r, company.RoleViewer)
+	if !ok {
+		return
+	}
+
+	notes, err := h.PoolRepo.GetNotes(actor.CompanyID, jobSeekerID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, notes)
+}
+
+// @Summary Add a candidate note
+// @Description Add a private note about the job seeker, shared with the company team. Requires the recruiter role or higher.
+// @Tags talent
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param jobSeekerID path int true "Job seeker ID"
+// @Param note body types.CreateNoteRequest true "Note"
+// @Success 201 {object} types.CandidateNote "Note created"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent/candidates/{jobSeekerID}/notes [post]
+func (h *Handler) handleCreateNote(w http.ResponseWriter, r *http.Request) {
+	actor, jobSeekerID, ok := h.candidateFromPath(w, r, company.RoleRecruiter)
+	if !ok {
+		return
+	}
+
+	var req types.CreateNoteRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req.Body = strings.TrimSpace(req.Body)
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	n := &types.CandidateNote{
+		CompanyID:   actor.CompanyID,
+		JobSeekerID: jobSeekerID,
+		AuthorID:    actor.UserID,
+		AuthorEmail: actor.Email,
+		Body:        req.Body,
+	}
+	if err := h.PoolRepo.CreateNote(n); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, n)
+}
+
+func parsePoolRequest(w http.ResponseWriter, r *http.Request) (*types.TalentPoolRequest, bool) {
+	var req types.TalentPoolRequest
+	if err := utils.ParseJSON(r, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return nil, false
+	}
+
+	return &req, true
+}
+
+func (h *Handler) poolFromPath(w http.ResponseWriter, r *http.Request, actor *types.CompanyMember) (*types.TalentPool, bool) {
+	poolID, err := strconv.Atoi(mux.Vars(r)["poolID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid pool id"))
+		return nil, false
+	}
+
+	p, err := h.PoolRepo.GetPool(actor.CompanyID, poolID)
+	if errors.Is(err, ErrPoolNotFound) {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	return p, true
+}
+
+// candidateFromPath checks the caller's seat and that the job seeker in the
+// path is visible to their company.
+func (h *Handler) candidateFromPath(
+	w http.ResponseWriter,
+	r *http.Request,
+	minRole string,
+) (*types.CompanyMember, int, bool) {
+	actor, ok := h.memberFromContext(w, r, minRole)
+	if !ok {
+		return nil, 0, false
+	}
+
+	jobSeekerID, err := strconv.Atoi(mux.Vars(r)["jobSeekerID"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid job seeker id"))
+		return nil, 0, false
+	}
+
+	if !h.checkCandidateVisible(w, jobSeekerID, actor) {
+		return nil, 0, false
+	}
+
+	return actor, jobSeekerID, true
+}
+
+// checkCandidateVisible answers 404 for job seekers the company may not see,
+// so hidden profiles cannot be told apart from missing ones.
+func (h *Handler) checkCandidateVisible(w http.ResponseWriter, jobSeekerID int, actor *types.CompanyMember) bool {
+	visible, err := h.PrivacyRepo.IsVisibleToCompany(jobSeekerID, actor.CompanyID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return false
+	}
+	if !visible {
+		utils.WriteError(w, http.StatusNotFound, fmt.Errorf("job seeker not found"))
+		return false
+	}
+
+	return true
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
@@ -0,0 +1,364 @@
+package talent
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/privacy"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/go-sql-driver/mysql"
+)
+
+const mysqlErrDuplicateEntry = 1062
+
+var (
+	ErrPoolNameTaken = errors.New("a pool with this name already exists")
+	ErrAlreadyInPool = errors.New("candidate is already in this pool")
+	ErrPoolNotFound  = errors.New("pool not found")
+	ErrNotInPool     = errors.New("candidate not found in pool")
+)
+
+type poolStore struct {
+	db *sql.DB
+}
+
+func NewPoolStore(db *sql.DB) types.TalentPoolRepository {
+	return &poolStore{
+		db: db,
+	}
+}
+
+func (s *poolStore) GetPools(companyID int) ([]types.TalentPool, error) {
+	args := append(privacy.VisibleToCompanyArgs(companyID), companyID)
+	rows, err := s.db.Query(selectPool+" WHERE p.companyID = ? GROUP BY p.id ORDER BY p.name", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pools := make([]types.TalentPool, 0)
+	for rows.Next() {
+		p, err := scanRowsIntoPool(rows)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, *p)
+	}
+
+	return pools, rows.Err()
+}
+
+func (s *poolStore) GetPool(companyID, poolID int) (*types.TalentPool, error) {
+	args := append(privacy.VisibleToCompanyArgs(companyID), poolID, companyID)
+	rows, err := s.db.Query(selectPool+" WHERE p.id = ? AND p.companyID = ? GROUP BY p.id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	p := new(types.TalentPool)
+	for rows.Next() {
+		p, err = scanRowsIntoPool(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.ID == 0 {
+		return nil, ErrPoolNotFound
+	}
+
+	return p, nil
+}
+
+func (s *poolStore) CreatePool(p *types.TalentPool) error {
+	res, err := s.db.Exec(
+		"INSERT INTO TalentPool (companyID, name, createdBy) VALUES (?, ?, ?)",
+		p.CompanyID,
+		p.Name,
+		p.CreatedBy,
+	)
+	if isDuplicateEntry(err) {
+		return ErrPoolNameTaken
+	}
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	p.ID = int(id)
+
+	return nil
+}
+
+func (s *poolStore) RenamePool(companyID, poolID int, name string) error {
+	_, err := s.db.Exec(
+		"UPDATE TalentPool SET name = ? WHERE id = ? AND companyID = ?",
+		name,
+		poolID,
+		companyID,
+	)
+	if isDuplicateEntry(err) {
+		return ErrPoolNameTaken
+	}
+
+	return err
+}
+
+// DeletePool removes the pool and its memberships. Tags and notes on the
+// candidates are kept.
+func (s *poolStore) DeletePool(companyID, poolID int) error {
+	res, err := s.db.Exec("DELETE FROM TalentPool WHERE id = ? AND companyID = ?", poolID, companyID)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrPoolNotFound
+	}
+
+	return nil
+}
+
+// GetPoolCandidates lists the pool's candidates, most recently added first,
+// optionally only those with tag. Candidates who have since hidden their
+// profile or blocked the company are left out.
+func (s *poolStore) GetPoolCandidates(p *types.TalentPool, tag string, limit, offset int) ([]types.PoolCandidate, error) {
+	args := []any{p.CompanyID, p.ID}
+	args = append(args, privacy.VisibleToCompanyArgs(p.CompanyID)...)
+	args = append(args, tag, p.CompanyID, tag, limit, offset)
+
+	rows, err := s.db.Query(
+		`SELECT js.id, js.firstName, js.lastName, COALESCE(js.profileSummary, ''), js.skills,
+			COALESCE(js.experience, 0), COALESCE(js.location, ''), js.openToWork,
+			(SELECT JSON_ARRAYAGG(t.tag) FROM CandidateTag t WHERE t.companyID = ? AND t.jobSeekerID = js.id),
+			pc.createdAt
+		FROM TalentPoolCandidate pc
+		JOIN JobSeeker js ON js.id = pc.jobSeekerID
+		WHERE pc.poolID = ? AND `+privacy.VisibleToCompany+`
+		AND (? = '' OR EXISTS (
+			SELECT 1 FROM CandidateTag t WHERE t.companyID = ? AND t.jobSeekerID = js.id AND t.tag = ?
+		))
+		ORDER BY pc.createdAt DESC, js.id DESC
+		LIMIT ? OFFSET ?`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := make([]types.PoolCandidate, 0)
+	for rows.Next() {
+		c, err := scanRowsIntoPoolCandidate(rows)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, *c)
+	}
+
+	return candidates, rows.Err()
+}
+
+func (s *poolStore) AddCandidate(poolID, jobSeekerID, addedBy int) error {
+	_, err := s.db.Exec(
+		"INSERT INTO TalentPoolCandidate (poolID, jobSeekerID, addedBy) VALUES (?, ?, ?)",
+		poolID,
+		jobSeekerID,
+		addedBy,
+	)
+	if isDuplicateEntry(err) {
+		return ErrAlreadyInPool
+	}
+
+	return err
+}
+
+func (s *poolStore) RemoveCandidate(poolID, jobSeekerID int) error {
+	res, err := s.db.Exec(
+		"DELETE FROM TalentPoolCandidate WHERE poolID = ? AND jobSeekerID = ?",
+		poolID,
+		jobSeekerID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotInPool
+	}
+
+	return nil
+}
+
+func (s *poolStore) GetTags(companyID, jobSeekerID int) ([]string, error) {
+	rows, err := s.db.Query(
+		"SELECT tag FROM CandidateTag WHERE companyID = ? AND jobSeekerID = ? ORDER BY tag",
+		companyID,
+		jobSeekerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]string, 0)
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// SetTags replaces the company's tags on the job seeker.
+func (s *poolStore) SetTags(companyID, jobSeekerID int, tags []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM CandidateTag WHERE companyID = ? AND jobSeekerID = ?", companyID, jobSeekerID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		_, err := tx.Exec(
+			"INSERT IGNORE INTO CandidateTag (companyID, jobSeekerID, tag) VALUES (?, ?, ?)",
+			companyID,
+			jobSeekerID,
+			tag,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *poolStore) GetNotes(companyID, jobSeekerID int) ([]types.CandidateNote, error) {
+	rows, err := s.db.Query(
+		`SELECT n.id, n.companyID, n.jobSeekerID, COALESCE(n.authorID, 0), COALESCE(u.email, ''), n.body, n.createdAt
+		FROM CandidateNote n
+		LEFT JOIN User u ON u.id = n.authorID
+		WHERE n.companyID = ? AND n.jobSeekerID = ?
+		ORDER BY n.createdAt DESC, n.id DESC`,
+		companyID,
+		jobSeekerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := make([]types.CandidateNote, 0)
+	for rows.Next() {
+		var n types.CandidateNote
+		err := rows.Scan(&n.ID, &n.CompanyID, &n.JobSeekerID, &n.AuthorID, &n.AuthorEmail, &n.Body, &n.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+
+	return notes, rows.Err()
+}
+
+func (s *poolStore) CreateNote(n *types.CandidateNote) error {
+	res, err := s.db.Exec(
+		"INSERT INTO CandidateNote (companyID, jobSeekerID, authorID, body) VALUES (?, ?, ?, ?)",
+		n.CompanyID,
+		n.JobSeekerID,
+		n.AuthorID,
+		n.Body,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	n.ID = int(id)
+
+	return nil
+}
+
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
+
+// selectPool only counts candidates still visible to the company, so the
+// count does not reveal hidden or blocking profiles. It takes
+// VisibleToCompanyArgs before any other arguments.
+const selectPool = `SELECT p.id, p.companyID, p.name, COUNT(js.id), COALESCE(p.createdBy, 0), p.createdAt
+	FROM TalentPool p
+	LEFT JOIN TalentPoolCandidate pc ON pc.poolID = p.id
+	LEFT JOIN JobSeeker js ON js.id = pc.jobSeekerID AND ` + privacy.VisibleToCompany
+
+func scanRowsIntoPool(rows *sql.Rows) (*types.TalentPool, error) {
+	p := new(types.TalentPool)
+
+	err := rows.Scan(
+		&p.ID,
+		&p.CompanyID,
+		&p.Name,
+		&p.CandidateCount,
+		&p.CreatedBy,
+		&p.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func scanRowsIntoPoolCandidate(rows *sql.Rows) (*types.PoolCandidate, error) {
+	c := new(types.PoolCandidate)
+
+	var skillsJSON, tagsJSON []byte
+	err := rows.Scan(
+		&c.JobSeekerID,
+		&c.FirstName,
+		&c.LastName,
+		&c.ProfileSummary,
+		&skillsJSON,
+		&c.Experience,
+		&c.Location,
+		&c.OpenToWork,
+		&tagsJSON,
+		&c.AddedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Skills, c.Tags = []string{}, []string{}
+	if len(skillsJSON) > 0 {
+		c.Skills, err = utils.DecodeJSONTOStringSlice(skillsJSON)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(tagsJSON) > 0 {
+		c.Tags, err = utils.DecodeJSONTOStringSlice(tagsJSON)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
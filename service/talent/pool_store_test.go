@@ -0,0 +1,141 @@
+package talent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
+	"github.com/AyKrimino/JobSeekerAPI/service/privacy"
+	"github.com/AyKrimino/JobSeekerAPI/service/talent"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/testutils"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+func TestPoolStore_CandidatesTagsAndNotes(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userStore := user.NewUserStore(db)
+
+	ownerID, err := userStore.CreateUser(&types.User{
+		Email:    "hr@acme.com",
+		Password: "Pass1234",
+		Role:     "Company",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+	cpy := &types.Company{Name: "Acme", UserID: ownerID}
+	if err := company.NewCompany(db).CreateCompany(cpy); err != nil {
+		t.Fatal("CreateCompany failed:", err)
+	}
+
+	seekerID, err := userStore.CreateUser(&types.User{
+		Email:    "seeker@test.com",
+		Password: "Pass1234",
+		Role:     "JobSeeker",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+	js := &types.JobSeeker{FirstName: "fname", LastName: "lname", Skills: []string{"Go"}, UserID: seekerID}
+	if err := jobseeker.NewJobseekerStore(db).CreateJobSeeker(js); err != nil {
+		t.Fatal("CreateJobSeeker failed:", err)
+	}
+
+	store := talent.NewPoolStore(db)
+
+	pool := &types.TalentPool{CompanyID: cpy.ID, Name: "Backend 2026", CreatedBy: ownerID}
+	if err := store.CreatePool(pool); err != nil {
+		t.Fatal("CreatePool failed:", err)
+	}
+
+	dup := &types.TalentPool{CompanyID: cpy.ID, Name: "Backend 2026", CreatedBy: ownerID}
+	if err := store.CreatePool(dup); !errors.Is(err, talent.ErrPoolNameTaken) {
+		t.Errorf("expected ErrPoolNameTaken, got %v", err)
+	}
+
+	if err := store.AddCandidate(pool.ID, js.ID, ownerID); err != nil {
+		t.Fatal("AddCandidate failed:", err)
+	}
+	if err := store.AddCandidate(pool.ID, js.ID, ownerID); !errors.Is(err, talent.ErrAlreadyInPool) {
+		t.Errorf("expected ErrAlreadyInPool, got %v", err)
+	}
+
+	if err := store.SetTags(cpy.ID, js.ID, []string{"strong go", "relocate"}); err != nil {
+		t.Fatal("SetTags failed:", err)
+	}
+	note := &types.CandidateNote{CompanyID: cpy.ID, JobSeekerID: js.ID, AuthorID: ownerID, Body: "Great call"}
+	if err := store.CreateNote(note); err != nil {
+		t.Fatal("CreateNote failed:", err)
+	}
+
+	pools, err := store.GetPools(cpy.ID)
+	if err != nil {
+		t.Fatal("GetPools failed:", err)
+	}
+	if len(pools) != 1 || pools[0].CandidateCount != 1 {
+		t.Fatalf("expected one pool with one candidate, got %+v", pools)
+	}
+
+	candidates, err := store.GetPoolCandidates(pool, "relocate", 20, 0)
+	if err != nil {
+		t.Fatal("GetPoolCandidates failed:", err)
+	}
+	if len(candidates) != 1 || candidates[0].JobSeekerID != js.ID || len(candidates[0].Tags) != 2 {
+		t.Fatalf("expected the tagged candidate, got %+v", candidates)
+	}
+
+	candidates, err = store.GetPoolCandidates(pool, "junior", 20, 0)
+	if err != nil {
+		t.Fatal("GetPoolCandidates failed:", err)
+	}
+	if len(candidates) != 0 {
+		t.Errorf("expected no candidates for an unused tag, got %+v", candidates)
+	}
+
+	err = privacy.NewPrivacyStore(db).UpdatePrivacySettings(js.ID, &types.PrivacySettings{Visibility: "hidden"})
+	if err != nil {
+		t.Fatal("UpdatePrivacySettings failed:", err)
+	}
+	candidates, err = store.GetPoolCandidates(pool, "", 20, 0)
+	if err != nil {
+		t.Fatal("GetPoolCandidates failed:", err)
+	}
+	if len(candidates) != 0 {
+		t.Errorf("expected hidden candidate to be left out, got %+v", candidates)
+	}
+
+	got, err := store.GetPool(cpy.ID, pool.ID)
+	if err != nil {
+		t.Fatal("GetPool failed:", err)
+	}
+	if got.CandidateCount != 0 {
+		t.Errorf("expected hidden candidate not to be counted, got %d", got.CandidateCount)
+	}
+
+	if err := store.RemoveCandidate(pool.ID, js.ID); err != nil {
+		t.Fatal("RemoveCandidate failed:", err)
+	}
+	if err := store.RemoveCandidate(pool.ID, js.ID); !errors.Is(err, talent.ErrNotInPool) {
+		t.Errorf("expected ErrNotInPool removing twice, got %v", err)
+	}
+
+	notes, err := store.GetNotes(cpy.ID, js.ID)
+	if err != nil {
+		t.Fatal("GetNotes failed:", err)
+	}
+	if len(notes) != 1 || notes[0].Body != "Great call" || notes[0].AuthorEmail != "hr@acme.com" {
+		t.Errorf("expected the note to outlive pool membership, got %+v", notes)
+	}
+
+	tags, err := store.GetTags(cpy.ID, js.ID)
+	if err != nil {
+		t.Fatal("GetTags failed:", err)
+	}
+	if len(tags) != 2 || tags[0] != "relocate" {
+		t.Errorf("expected tags to outlive pool membership, got %v", tags)
+	}
+}
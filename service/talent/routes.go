@@ -0,0 +1,161 @@
+package talent
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/service/privacy"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+	"github.com/gorilla/mux"
+)
+
+type Handler struct {
+	TalentRepo  types.TalentRepository
+	PoolRepo    types.TalentPoolRepository
+	PrivacyRepo types.PrivacyRepository
+	MemberRepo  types.CompanyMemberRepository
+	UserRepo    types.UserRepository
+}
+
+func NewHandler(db *sql.DB) *Handler {
+	return &Handler{
+		TalentRepo:  NewTalentStore(db),
+		PoolRepo:    NewPoolStore(db),
+		PrivacyRepo: privacy.NewPrivacyStore(db),
+		MemberRepo:  company.NewMemberStore(db),
+		UserRepo:    user.NewUserStore(db),
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/talent", auth.WithJWTAuth(h.handleSearchTalent, h.UserRepo)).Methods("GET")
+
+	h.registerPoolRoutes(router)
+}
+
+// @Summary Search talent
+// @Description Search job seekers visible to the caller's company, ordered by the number of requested skills matched, then open to work, then experience. Hidden profiles and job seekers who blocked the company are never returned. Requires a seat on a company team.
+// @Tags talent
+// @Produce json
+// @Security BearerAuth
+// @Param skills query string false "Comma-separated skills the candidate must all have"
+// @Param anySkills query string false "Comma-separated skills of which the candidate must have at least one"
+// @Param minExperience query int false "Minimum years of experience"
+// @Param maxExperience query int false "Maximum years of experience"
+// @Param education query string false "Keywords that must each appear in the candidate's education"
+// @Param location query string false "Location prefix"
+// @Param openToWork query bool false "Only candidates open to work"
+// @Param page query int false "Page number (default 1)"
+// @Param pageSize query int false "Page size (default 20, max 100)"
+// @Success 200 {array} types.TalentResult "Candidates"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/talent [get]
+func (h *Handler) handleSearchTalent(w http.ResponseWriter, r *http.Request) {
+	m, ok := h.memberFromContext(w, r, company.RoleViewer)
+	if !ok {
+		return
+	}
+
+	q, err := parseSearchQuery(r.URL.Query())
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	q.Limit, q.Offset, err = utils.ParsePagination(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	results, err := h.TalentRepo.SearchTalent(m.CompanyID, q)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, results)
+}
+
+// memberFromContext returns the caller's seat on their company team if it
+// is minRole or higher. Talent routes always act on that company.
+func (h *Handler) memberFromContext(w http.ResponseWriter, r *http.Request, minRole string) (*types.CompanyMember, bool) {
+	m, err := h.MemberRepo.GetMembershipByUserID(auth.GetUserIDFromContext(r.Context()))
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	if m == nil {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("only company team members can access talent"))
+		return nil, false
+	}
+	if !company.HasRole(m, minRole) {
+		utils.WriteError(w, http.StatusForbidden, fmt.Errorf("permission denied"))
+		return nil, false
+	}
+
+	return m, true
+}
+
+func parseSearchQuery(values url.Values) (*types.TalentSearchQuery, error) {
+	q := &types.TalentSearchQuery{
+		AllSkills:         splitList(values.Get("skills")),
+		AnySkills:         splitList(values.Get("anySkills")),
+		EducationKeywords: strings.Fields(values.Get("education")),
+		Location:          strings.TrimSpace(values.Get("location")),
+	}
+
+	var err error
+	if q.MinExperience, err = parseExperience(values, "minExperience"); err != nil {
+		return nil, err
+	}
+	if q.MaxExperience, err = parseExperience(values, "maxExperience"); err != nil {
+		return nil, err
+	}
+	if q.MinExperience != nil && q.MaxExperience != nil && *q.MinExperience > *q.MaxExperience {
+		return nil, fmt.Errorf("minExperience must not be greater than maxExperience")
+	}
+
+	if v := values.Get("openToWork"); v != "" {
+		q.OpenToWork, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("openToWork must be true or false")
+		}
+	}
+
+	return q, nil
+}
+
+func parseExperience(values url.Values, key string) (*int, error) {
+	v := values.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+
+	years, err := strconv.Atoi(v)
+	if err != nil || years < 0 || years > 50 {
+		return nil, fmt.Errorf("%s must be between 0 and 50", key)
+	}
+
+	return &years, nil
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
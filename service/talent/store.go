@@ -0,0 +1,180 @@
+package talent
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/privacy"
+	"github.com/AyKrimino/JobSeekerAPI/service/skill"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+	"github.com/AyKrimino/JobSeekerAPI/utils"
+)
+
+type talentStore struct {
+	db *sql.DB
+}
+
+func NewTalentStore(db *sql.DB) types.TalentRepository {
+	return &talentStore{
+		db: db,
+	}
+}
+
+// SearchTalent returns the job seekers visible to companyID that match q,
+// most requested skills first, then open to work, then most experienced.
+//
+// Skills are matched through the indexed JobSeekerSkill table rather than
+// the JSON skills column, so a skill filter only reads the rows of the
+// requested skills.
+func (s *talentStore) SearchTalent(companyID int, q *types.TalentSearchQuery) ([]types.TalentResult, error) {
+	allIDs, unknown, err := skill.LookupSkillIDs(s.db, q.AllSkills)
+	if err != nil {
+		return nil, err
+	}
+	if len(unknown) > 0 {
+		// Nobody can have a skill that is not in the catalogue.
+		return []types.TalentResult{}, nil
+	}
+
+	anyIDs, _, err := skill.LookupSkillIDs(s.db, q.AnySkills)
+	if err != nil {
+		return nil, err
+	}
+	if len(anyIDs) == 0 && hasNames(q.AnySkills) {
+		return []types.TalentResult{}, nil
+	}
+
+	var (
+		query strings.Builder
+		args  []any
+	)
+
+	skillIDs := append(append([]int{}, allIDs...), anyIDs...)
+	matched := "0"
+	if len(skillIDs) > 0 {
+		matched = "COUNT(jss.skillID)"
+	}
+
+	query.WriteString(`SELECT js.id, js.firstName, js.lastName, COALESCE(js.profileSummary, ''), js.skills,
+		COALESCE(js.experience, 0), COALESCE(js.location, ''), js.openToWork, ` + matched + `
+		FROM JobSeeker js`)
+
+	if len(skillIDs) > 0 {
+		query.WriteString(" JOIN JobSeekerSkill jss ON jss.jobSeekerID = js.id AND jss.skillID IN (" + placeholders(len(skillIDs)) + ")")
+		for _, id := range skillIDs {
+			args = append(args, id)
+		}
+	}
+
+	query.WriteString(" WHERE " + privacy.VisibleToCompany)
+	args = append(args, privacy.VisibleToCompanyArgs(companyID)...)
+
+	if q.MinExperience != nil {
+		query.WriteString(" AND js.experience >= ?")
+		args = append(args, *q.MinExperience)
+	}
+	if q.MaxExperience != nil {
+		query.WriteString(" AND js.experience <= ?")
+		args = append(args, *q.MaxExperience)
+	}
+	if q.Location != "" {
+		query.WriteString(` AND js.location LIKE ?`)
+		args = append(args, utils.EscapeLike(q.Location)+"%")
+	}
+	if q.OpenToWork {
+		query.WriteString(" AND js.openToWork")
+	}
+	for _, keyword := range q.EducationKeywords {
+		query.WriteString(` AND EXISTS (
+			SELECT 1 FROM Education e
+			WHERE e.jobSeekerID = js.id
+			AND CONCAT_WS(' ', e.institution, e.degree, e.fieldOfStudy) LIKE ?
+		)`)
+		args = append(args, "%"+utils.EscapeLike(keyword)+"%")
+	}
+
+	if len(skillIDs) > 0 {
+		query.WriteString(" GROUP BY js.id")
+	}
+
+	var having []string
+	if len(allIDs) > 0 {
+		having = append(having, "SUM(jss.skillID IN ("+placeholders(len(allIDs))+")) = ?")
+		for _, id := range allIDs {
+			args = append(args, id)
+		}
+		args = append(args, len(allIDs))
+	}
+	if len(anyIDs) > 0 {
+		having = append(having, "SUM(jss.skillID IN ("+placeholders(len(anyIDs))+")) > 0")
+		for _, id := range anyIDs {
+			args = append(args, id)
+		}
+	}
+	if len(having) > 0 {
+		query.WriteString(" HAVING " + strings.Join(having, " AND "))
+	}
+
+	query.WriteString(" ORDER BY " + matched + " DESC, js.openToWork DESC, js.experience DESC, js.id LIMIT ? OFFSET ?")
+	args = append(args, q.Limit, q.Offset)
+
+	rows, err := s.db.Query(query.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]types.TalentResult, 0)
+	for rows.Next() {
+		t, err := scanRowsIntoTalentResult(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *t)
+	}
+
+	return results, rows.Err()
+}
+
+func hasNames(names []string) bool {
+	for _, name := range names {
+		if skill.NormalizeName(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func scanRowsIntoTalentResult(rows *sql.Rows) (*types.TalentResult, error) {
+	t := new(types.TalentResult)
+
+	var skillsJSON []byte
+	err := rows.Scan(
+		&t.JobSeekerID,
+		&t.FirstName,
+		&t.LastName,
+		&t.ProfileSummary,
+		&skillsJSON,
+		&t.Experience,
+		&t.Location,
+		&t.OpenToWork,
+		&t.MatchedSkills,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Skills = []string{}
+	if len(skillsJSON) > 0 {
+		t.Skills, err = utils.DecodeJSONTOStringSlice(skillsJSON)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,128 @@
+package talent_test
+
+import (
+	"testing"
+
+	"github.com/AyKrimino/JobSeekerAPI/service/company"
+	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
+	"github.com/AyKrimino/JobSeekerAPI/service/privacy"
+	"github.com/AyKrimino/JobSeekerAPI/service/talent"
+	"github.com/AyKrimino/JobSeekerAPI/service/user"
+	"github.com/AyKrimino/JobSeekerAPI/testutils"
+	"github.com/AyKrimino/JobSeekerAPI/types"
+)
+
+func TestTalentStore_SearchTalent(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userStore := user.NewUserStore(db)
+	jobSeekerStore := jobseeker.NewJobseekerStore(db)
+
+	ownerID, err := userStore.CreateUser(&types.User{
+		Email:    "hr@acme.com",
+		Password: "Pass1234",
+		Role:     "Company",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+	cpy := &types.Company{Name: "Acme", UserID: ownerID}
+	if err := company.NewCompany(db).CreateCompany(cpy); err != nil {
+		t.Fatal("CreateCompany failed:", err)
+	}
+
+	createJobSeeker := func(email string, js *types.JobSeeker) *types.JobSeeker {
+		t.Helper()
+		userID, err := userStore.CreateUser(&types.User{
+			Email:    email,
+			Password: "Pass1234",
+			Role:     "JobSeeker",
+		})
+		if err != nil {
+			t.Fatal("CreateUser failed:", err)
+		}
+		js.FirstName, js.LastName, js.UserID = "fname", "lname", userID
+		if err := jobSeekerStore.CreateJobSeeker(js); err != nil {
+			t.Fatal("CreateJobSeeker failed:", err)
+		}
+		return js
+	}
+
+	gopher := createJobSeeker("gopher@test.com", &types.JobSeeker{
+		Skills:     []string{"Go", "Docker", "Kubernetes"},
+		Experience: 6,
+		Education:  "MSc Computer Science",
+		Location:   "Berlin, Germany",
+	})
+	pythonista := createJobSeeker("pythonista@test.com", &types.JobSeeker{
+		Skills:     []string{"Python", "Docker"},
+		Experience: 2,
+		Education:  "BSc Mathematics",
+		Location:   "Paris, France",
+	})
+	hidden := createJobSeeker("hidden@test.com", &types.JobSeeker{
+		Skills:     []string{"Go", "Docker"},
+		Experience: 4,
+	})
+
+	privacyStore := privacy.NewPrivacyStore(db)
+	err = privacyStore.UpdatePrivacySettings(hidden.ID, &types.PrivacySettings{Visibility: "hidden"})
+	if err != nil {
+		t.Fatal("UpdatePrivacySettings failed:", err)
+	}
+	err = privacyStore.UpdatePrivacySettings(pythonista.ID, &types.PrivacySettings{Visibility: "companies", OpenToWork: true})
+	if err != nil {
+		t.Fatal("UpdatePrivacySettings failed:", err)
+	}
+
+	store := talent.NewTalentStore(db)
+
+	search := func(q types.TalentSearchQuery) []int {
+		t.Helper()
+		q.Limit = 20
+		results, err := store.SearchTalent(cpy.ID, &q)
+		if err != nil {
+			t.Fatal("SearchTalent failed:", err)
+		}
+		ids := make([]int, 0, len(results))
+		for _, r := range results {
+			ids = append(ids, r.JobSeekerID)
+		}
+		return ids
+	}
+
+	assertIDs := func(name string, got []int, want ...int) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %v, got %v", name, want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: expected %v, got %v", name, want, got)
+			}
+		}
+	}
+
+	assertIDs("no filters", search(types.TalentSearchQuery{}), pythonista.ID, gopher.ID)
+	assertIDs("all of", search(types.TalentSearchQuery{AllSkills: []string{"golang", "docker"}}), gopher.ID)
+	assertIDs("unknown skill", search(types.TalentSearchQuery{AllSkills: []string{"Go", "Cobol"}}))
+	assertIDs(
+		"any of ranks by matches",
+		search(types.TalentSearchQuery{AnySkills: []string{"Go", "Docker", "Python", "Cobol"}}),
+		gopher.ID,
+		pythonista.ID,
+	)
+
+	minYears, maxYears := 3, 10
+	assertIDs("experience", search(types.TalentSearchQuery{MinExperience: &minYears, MaxExperience: &maxYears}), gopher.ID)
+	assertIDs("location", search(types.TalentSearchQuery{Location: "paris"}), pythonista.ID)
+	assertIDs("education", search(types.TalentSearchQuery{EducationKeywords: []string{"computer", "msc"}}), gopher.ID)
+	assertIDs("open to work", search(types.TalentSearchQuery{OpenToWork: true}), pythonista.ID)
+
+	block := &types.JobSeekerBlock{JobSeekerID: gopher.ID, CompanyID: &cpy.ID}
+	if err := privacyStore.CreateBlock(block); err != nil {
+		t.Fatal("CreateBlock failed:", err)
+	}
+	assertIDs("blocked", search(types.TalentSearchQuery{AllSkills: []string{"Go"}}))
+}
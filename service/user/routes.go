@@ -1,8 +1,11 @@
 package user
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -21,6 +24,7 @@ type Handler struct {
 	UserRepo      types.UserRepository
 	JobSeekerRepo types.JobSeekerRepository
 	CompanyRepo   types.CompanyRepository
+	MemberRepo    types.CompanyMemberRepository
 }
 
 func NewHandler(db *sql.DB) *Handler {
@@ -28,12 +32,15 @@ func NewHandler(db *sql.DB) *Handler {
 		UserRepo:      NewUserStore(db),
 		JobSeekerRepo: jobseeker.NewJobseekerStore(db),
 		CompanyRepo:   company.NewCompany(db),
+		MemberRepo:    company.NewMemberStore(db),
 	}
 }
 
 func (h *Handler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/register", h.handleRegister).Methods("POST")
 	router.HandleFunc("/login", h.handleLogin).Methods("POST")
+	router.HandleFunc("/me", auth.WithJWTAuth(h.handleGetMe, h.UserRepo)).Methods("GET")
+	router.HandleFunc("/me", auth.WithJWTAuth(h.handleUpdateMe, h.UserRepo)).Methods("PATCH")
 }
 
 // @Summary Register a new user
@@ -161,6 +168,186 @@ func (h *Handler) handleLogin(w http.ResponseWriter, r *http.Request) {
 	utils.WriteJSON(w, http.StatusOK, map[string]string{"token": token})
 }
 
+// @Summary Get the current user
+// @Description Return the authenticated user's account and the profile for their role.
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.MeResponse "Current user"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Router /api/v1/me [get]
+func (h *Handler) handleGetMe(w http.ResponseWriter, r *http.Request) {
+	me, err := h.getMe(auth.GetUserIDFromContext(r.Context()))
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, me)
+}
+
+// @Summary Update the current user's profile
+// @Description Apply a JSON Merge Patch (RFC 7386) to the authenticated user's profile. Members set to null are cleared, and the result must pass the same rules as registration for the user's role. Account fields such as email and password cannot be changed here, experience and education are derived from /me/positions and /me/education.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param patch body types.UpdateProfileRequest true "Merge patch. Example (JobSeeker): {\"profileSummary\": \"Backend engineer\", \"skills\": [\"go\", \"sql\"]}"
+// @Success 200 {object} types.MeResponse "Updated user"
+// @Failure 400 {object} map[string]string "Bad Request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Not Found"
+// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Router /api/v1/me [patch]
+func (h *Handler) handleUpdateMe(w http.ResponseWriter, r *http.Request) {
+	me, err := h.getMe(auth.GetUserIDFromContext(r.Context()))
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err)
+		return
+	}
+
+	if r.Body == nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("missing request body"))
+		return
+	}
+	patch, err := io.ReadAll(io.LimitReader(r.Body, maxPatchSize))
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var current any
+	switch {
+	case me.JobSeeker != nil:
+		current = jobSeekerRequestFromProfile(me.JobSeeker)
+	case me.Company != nil:
+		if !company.RoleAtLeast(me.CompanyRole, company.RoleAdmin) {
+			utils.WriteError(w, http.StatusForbidden, fmt.Errorf("only company owners and admins can edit the company profile"))
+			return
+		}
+		current = company.RequestFromCompany(me.Company)
+	default:
+		utils.WriteError(w, http.StatusNotFound, fmt.Errorf("profile not found"))
+		return
+	}
+
+	doc, err := json.Marshal(current)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	merged, err := utils.MergePatch(doc, patch)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req := types.UpdateProfileRequest{Role: me.Role}
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	if err := utils.Validate.Struct(req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request %v", err))
+		return
+	}
+
+	if me.JobSeeker != nil && req.Experience != me.JobSeeker.Experience {
+		utils.WriteError(
+			w,
+			http.StatusBadRequest,
+			fmt.Errorf("experience is derived from positions, edit /me/positions instead"),
+		)
+		return
+	}
+	if me.JobSeeker != nil && req.Education != me.JobSeeker.Education {
+		utils.WriteError(
+			w,
+			http.StatusBadRequest,
+			fmt.Errorf("education is derived from education records, edit /me/education instead"),
+		)
+		return
+	}
+
+	if me.JobSeeker != nil {
+		applyJobSeekerRequest(me.JobSeeker, &req.JobSeekerRequest)
+		err = h.JobSeekerRepo.UpdateJobSeeker(me.JobSeeker)
+	} else {
+		applyCompanyRequest(me.Company, &req.CompanyRequest)
+		err = h.CompanyRepo.UpdateCompany(me.Company)
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, me)
+}
+
+const maxPatchSize = 1 << 20
+
+func (h *Handler) getMe(userID int) (*types.MeResponse, error) {
+	u, err := h.UserRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	me := &types.MeResponse{User: u}
+	switch strings.ToLower(u.Role) {
+	case "jobseeker":
+		me.JobSeeker, err = h.JobSeekerRepo.GetJobSeekerByUserID(u.ID)
+	case "company":
+		// Members removed from their team keep their account but have no
+		// company profile.
+		var m *types.CompanyMember
+		m, err = h.MemberRepo.GetMembershipByUserID(u.ID)
+		if err == nil && m != nil {
+			me.CompanyRole = m.Role
+			me.Company, err = h.CompanyRepo.GetCompanyByID(m.CompanyID)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return me, nil
+}
+
+func jobSeekerRequestFromProfile(js *types.JobSeeker) types.JobSeekerRequest {
+	return types.JobSeekerRequest{
+		FirstName:      js.FirstName,
+		LastName:       js.LastName,
+		ProfileSummary: js.ProfileSummary,
+		Skills:         js.Skills,
+		Experience:     js.Experience,
+		Education:      js.Education,
+		Location:       js.Location,
+	}
+}
+
+func applyJobSeekerRequest(js *types.JobSeeker, req *types.JobSeekerRequest) {
+	js.FirstName = req.FirstName
+	js.LastName = req.LastName
+	js.ProfileSummary = req.ProfileSummary
+	js.Skills = req.Skills
+	js.Experience = req.Experience
+	js.Education = req.Education
+	js.Location = req.Location
+}
+
+func applyCompanyRequest(cpy *types.Company, req *types.CompanyRequest) {
+	cpy.Name = req.Name
+	cpy.Headquarters = req.Headquarters
+	cpy.Website = req.Website
+	cpy.Industry = req.Industry
+	cpy.CompanySize = req.CompanySize
+}
+
 func parseUserFromRequest(req *types.RegisterUserRequest) *types.User {
 	return &types.User{
 		Email:    req.Email,
@@ -177,6 +364,7 @@ func parseJobSeekerFromRequest(req *types.RegisterUserRequest, userID int) *type
 		Skills:         req.Skills,
 		Experience:     req.Experience,
 		Education:      req.Education,
+		Location:       req.Location,
 		UserID:         userID,
 	}
 }
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"database/sql"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/AyKrimino/JobSeekerAPI/service/auth"
 	"github.com/AyKrimino/JobSeekerAPI/service/company"
 	"github.com/AyKrimino/JobSeekerAPI/service/jobseeker"
 	"github.com/AyKrimino/JobSeekerAPI/testutils"
@@ -20,6 +22,7 @@ func SetupHandlerWithDB(t *testing.T) (*Handler, *sql.DB) {
 		UserRepo:      NewUserStore(db),
 		JobSeekerRepo: jobseeker.NewJobseekerStore(db),
 		CompanyRepo:   company.NewCompany(db),
+		MemberRepo:    company.NewMemberStore(db),
 	}
 
 	return handler, db
@@ -390,3 +393,138 @@ func TestHandleLogin(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleUpdateMe(t *testing.T) {
+	handler, db := SetupHandlerWithDB(t)
+	defer db.Close()
+
+	registerReqBody := `{
+	"email": "me@example.com",
+	"password": "password123",
+	"role": "JobSeeker",
+	"firstName": "John",
+	"lastName": "Doe",
+	"profileSummary": "Software Engineer",
+	"skills": ["Go", "Python"],
+	"education": "BSc Computer Science"
+	}`
+
+	req := httptest.NewRequest("POST", "/register", strings.NewReader(registerReqBody))
+	rec := httptest.NewRecorder()
+	handler.handleRegister(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", rec.Code)
+	}
+
+	u, err := handler.UserRepo.GetUserByEmail("me@example.com")
+	if err != nil {
+		t.Fatalf("failed to fetch user from DB: %v", err)
+	}
+
+	patchMe := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PATCH", "/me", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserKey, u.ID))
+		rec := httptest.NewRecorder()
+		handler.handleUpdateMe(rec, req)
+		return rec
+	}
+
+	t.Run("Merge patch updates only given fields", func(t *testing.T) {
+		rec := patchMe(`{"profileSummary": "Backend Engineer"}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status code 200, got %d: %s", rec.Code, rec.Body)
+		}
+
+		body, _ := io.ReadAll(rec.Body)
+		if strings.Contains(string(body), "password") {
+			t.Errorf("response must not contain the password hash: %s", body)
+		}
+
+		js, err := handler.JobSeekerRepo.GetJobSeekerByUserID(u.ID)
+		if err != nil {
+			t.Fatalf("failed to fetch job seeker from DB: %v", err)
+		}
+		if js.ProfileSummary != "Backend Engineer" || js.Education != "BSc Computer Science" || js.FirstName != "John" {
+			t.Errorf("unexpected profile after patch: %+v", js)
+		}
+	})
+
+	t.Run("Company fields are rejected for job seekers", func(t *testing.T) {
+		rec := patchMe(`{"name": "Acme"}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status code 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Required fields cannot be cleared", func(t *testing.T) {
+		rec := patchMe(`{"firstName": null}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status code 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Account fields cannot be patched", func(t *testing.T) {
+		rec := patchMe(`{"email": "other@example.com"}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status code 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Derived fields cannot be patched", func(t *testing.T) {
+		for _, body := range []string{`{"experience": 5}`, `{"education": null}`} {
+			rec := patchMe(body)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected status code 400 for %s, got %d", body, rec.Code)
+			}
+		}
+	})
+}
+
+func TestHandleUpdateMe_Company(t *testing.T) {
+	handler, db := SetupHandlerWithDB(t)
+	defer db.Close()
+
+	registerReqBody := `{
+	"email": "acme@example.com",
+	"password": "password123",
+	"role": "Company",
+	"name": "Acme Corp",
+	"website": "https://acme.com"
+	}`
+
+	req := httptest.NewRequest("POST", "/register", strings.NewReader(registerReqBody))
+	rec := httptest.NewRecorder()
+	handler.handleRegister(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d: %s", rec.Code, rec.Body)
+	}
+
+	u, err := handler.UserRepo.GetUserByEmail("acme@example.com")
+	if err != nil {
+		t.Fatalf("failed to fetch user from DB: %v", err)
+	}
+
+	patchMe := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PATCH", "/me", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req = req.WithContext(context.WithValue(req.Context(), auth.UserKey, u.ID))
+		rec := httptest.NewRecorder()
+		handler.handleUpdateMe(rec, req)
+		return rec
+	}
+
+	t.Run("Other fields can be patched next to a website", func(t *testing.T) {
+		rec := patchMe(`{"industry": "Manufacturing"}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status code 200, got %d: %s", rec.Code, rec.Body)
+		}
+	})
+
+	t.Run("Invalid website is rejected", func(t *testing.T) {
+		rec := patchMe(`{"website": "not a url"}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status code 400, got %d", rec.Code)
+		}
+	})
+}
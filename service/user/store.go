@@ -39,6 +39,28 @@ func (s *userStore) GetUserByEmail(e string) (*types.User, error) {
 	return u, nil
 }
 
+func (s *userStore) GetUserByID(id int) (*types.User, error) {
+	rows, err := s.db.Query("SELECT * FROM User WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	u := new(types.User)
+	for rows.Next() {
+		u, err = scanRowsIntoUser(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if u.ID == 0 {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	return u, nil
+}
+
 func (s *userStore) CreateUser(u *types.User) (int, error) {
 	now := time.Now().UTC()
 
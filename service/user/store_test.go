@@ -122,3 +122,32 @@ func TestGetUserByEmail_NotFound(t *testing.T) {
 		t.Error("expected error for non-existent user but got nil")
 	}
 }
+
+func TestGetUserByID(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	defer db.Close()
+
+	userStore := NewUserStore(db)
+
+	userID, err := userStore.CreateUser(&types.User{
+		Email:    "byid@test.com",
+		Password: "pass1234",
+		Role:     "JobSeeker",
+	})
+	if err != nil {
+		t.Fatal("CreateUser failed:", err)
+	}
+
+	u, err := userStore.GetUserByID(userID)
+	if err != nil {
+		t.Fatal("GetUserByID failed:", err)
+	}
+	if u.Email != "byid@test.com" {
+		t.Errorf("expected email byid@test.com, got %s", u.Email)
+	}
+
+	_, err = userStore.GetUserByID(userID + 1)
+	if err == nil {
+		t.Error("expected error for non-existent user but got nil")
+	}
+}
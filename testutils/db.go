@@ -35,11 +35,38 @@ func SetupTestDB(t *testing.T) *sql.DB {
 		t.Fatal("Failed to disable FK checks:", err)
 	}
 	
+	for _, table := range []string{
+		"Notification",
+		"NotificationPreference",
+		"NotificationDigestItem",
+		"CompanyReview",
+		"PositionSkill",
+		"Position",
+		"Education",
+		"CompanyMember",
+		"CompanyInvitation",
+		"JobSeekerBlock",
+		"TalentPoolCandidate",
+		"TalentPool",
+		"CandidateTag",
+		"CandidateNote",
+	} {
+		_, err = db.Exec("DELETE FROM " + table)
+		if err != nil {
+			t.Fatalf("Failed to clean %s: %v", table, err)
+		}
+	}
+	
 	_, err = db.Exec("DELETE FROM Company")
 	if err != nil {
 		t.Fatal("Failed to clean Company:", err)
 	}
 	
+	_, err = db.Exec("DELETE FROM JobSeekerSkill")
+	if err != nil {
+		t.Fatal("Failed to clean JobSeekerSkill:", err)
+	}
+	
 	_, err = db.Exec("DELETE FROM JobSeeker")
 	if err != nil {
 		t.Fatal("Failed to clean JobSeeker:", err)
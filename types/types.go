@@ -9,7 +9,7 @@ type SuccessResponse struct {
 type User struct {
 	ID        int       `json:"id"`
 	Email     string    `json:"email"`
-	Password  string    `json:"password"`
+	Password  string    `json:"-"`
 	Role      string    `json:"role"`
 	IsActive  bool      `json:"isActive"`
 	CreatedAt time.Time `json:"createdAt"`
@@ -24,6 +24,7 @@ type JobSeeker struct {
 	Skills         []string `json:"skills"`
 	Experience     int      `json:"experience"`
 	Education      string   `json:"education"`
+	Location       string   `json:"location"`
 	UserID         int      `json:"userId"`
 }
 
@@ -37,17 +38,338 @@ type Company struct {
 	UserID       int    `json:"userId"`
 }
 
+// Position is an entry in a job seeker's work history. Dates are formatted
+// as YYYY-MM-DD and a nil EndDate marks the current position.
+type Position struct {
+	ID          int      `json:"id"`
+	JobSeekerID int      `json:"jobSeekerId"`
+	Title       string   `json:"title"`
+	Employer    string   `json:"employer"`
+	StartDate   string   `json:"startDate"`
+	EndDate     *string  `json:"endDate"`
+	Description string   `json:"description"`
+	Skills      []string `json:"skills"`
+}
+
+type Education struct {
+	ID           int     `json:"id"`
+	JobSeekerID  int     `json:"jobSeekerId"`
+	Institution  string  `json:"institution"`
+	Degree       string  `json:"degree"`
+	FieldOfStudy string  `json:"fieldOfStudy"`
+	StartDate    *string `json:"startDate"`
+	EndDate      *string `json:"endDate"`
+}
+
+// PrivacySettings control who can find a job seeker. Visibility is public,
+// companies (the default) or hidden; hidden profiles never appear in
+// company-facing queries.
+type PrivacySettings struct {
+	Visibility string `json:"visibility"`
+	OpenToWork bool   `json:"openToWork"`
+}
+
+// JobSeekerBlock hides a job seeker from one company, or from every company
+// with a member whose email is at EmailDomain.
+type JobSeekerBlock struct {
+	ID          int       `json:"id"`
+	JobSeekerID int       `json:"jobSeekerId"`
+	CompanyID   *int      `json:"companyId,omitempty"`
+	CompanyName string    `json:"companyName,omitempty"`
+	EmailDomain string    `json:"emailDomain,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// TalentResult is a job seeker as seen by a company searching for
+// candidates. MatchedSkills counts the requested skills the candidate has.
+type TalentResult struct {
+	JobSeekerID    int      `json:"jobSeekerId"`
+	FirstName      string   `json:"firstName"`
+	LastName       string   `json:"lastName"`
+	ProfileSummary string   `json:"profileSummary"`
+	Skills         []string `json:"skills"`
+	Experience     int      `json:"experience"`
+	Location       string   `json:"location"`
+	OpenToWork     bool     `json:"openToWork"`
+	MatchedSkills  int      `json:"matchedSkills"`
+}
+
+// TalentPool is a named list of candidates kept by a company and shared
+// with its whole team.
+type TalentPool struct {
+	ID             int       `json:"id"`
+	CompanyID      int       `json:"companyId"`
+	Name           string    `json:"name"`
+	CandidateCount int       `json:"candidateCount"`
+	CreatedBy      int       `json:"createdBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// PoolCandidate is a pool member with the tags their company gave them.
+type PoolCandidate struct {
+	TalentResult
+	Tags    []string  `json:"tags"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// CandidateNote is a company's private note about a job seeker. Notes and
+// tags belong to the company rather than a pool, so they outlive pool
+// membership.
+type CandidateNote struct {
+	ID          int       `json:"id"`
+	CompanyID   int       `json:"companyId"`
+	JobSeekerID int       `json:"jobSeekerId"`
+	AuthorID    int       `json:"authorId"`
+	AuthorEmail string    `json:"authorEmail"`
+	Body        string    `json:"body"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// TalentSearchQuery filters a talent search. Candidates must have every
+// skill in AllSkills and at least one in AnySkills when those are set.
+// EducationKeywords must each appear in one of the candidate's education
+// records, and Location matches as a prefix.
+type TalentSearchQuery struct {
+	AllSkills         []string
+	AnySkills         []string
+	MinExperience     *int
+	MaxExperience     *int
+	EducationKeywords []string
+	Location          string
+	OpenToWork        bool
+	Limit             int
+	Offset            int
+}
+
+// CompanyMember is a user's seat on a company team. Role is one of owner,
+// admin, recruiter or viewer; each company has exactly one owner.
+type CompanyMember struct {
+	CompanyID int       `json:"companyId"`
+	UserID    int       `json:"userId"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CompanyInvitation is a pending seat offered to an email address. Only a
+// hash of the token sent by email is stored.
+type CompanyInvitation struct {
+	ID         int        `json:"id"`
+	CompanyID  int        `json:"companyId"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	InvitedBy  int        `json:"invitedBy"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// CompanyProfile is the public view of a company. It leaves out the owning
+// user and adds aggregates computed from approved reviews.
+type CompanyProfile struct {
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	Headquarters  string  `json:"headquarters"`
+	Website       string  `json:"website"`
+	Industry      string  `json:"industry"`
+	CompanySize   string  `json:"companySize"`
+	AverageRating float64 `json:"averageRating"`
+	ReviewCount   int     `json:"reviewCount"`
+}
+
+type Skill struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+}
+
+// SkillTerm is one alias of a catalogue skill, used to recognise the skill in
+// free text. Ambiguous aliases are ordinary words too ("go", "excel").
+type SkillTerm struct {
+	Name      string
+	Alias     string
+	Ambiguous bool
+}
+
+type Notification struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"userId"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Link      string     `json:"link,omitempty"`
+	ReadAt    *time.Time `json:"readAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// NotificationPreference selects the channels used for one event type.
+type NotificationPreference struct {
+	EventType string `json:"eventType"`
+	InApp     bool   `json:"inApp"`
+	Email     bool   `json:"email"`
+	Digest    bool   `json:"digest"`
+}
+
+type CompanyReview struct {
+	ID               int        `json:"id"`
+	CompanyID        int        `json:"companyId"`
+	UserID           int        `json:"userId"`
+	OverallRating    int        `json:"overallRating"`
+	CultureRating    int        `json:"cultureRating"`
+	PayRating        int        `json:"payRating"`
+	ManagementRating int        `json:"managementRating"`
+	Pros             string     `json:"pros"`
+	Cons             string     `json:"cons"`
+	IsAnonymous      bool       `json:"isAnonymous"`
+	Status           string     `json:"status"`
+	ModerationNote   string     `json:"moderationNote,omitempty"`
+	Reply            string     `json:"reply,omitempty"`
+	RepliedAt        *time.Time `json:"repliedAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// PublicCompanyReview is an approved review as shown on a company page. It
+// never identifies the author beyond a display name, and anonymous reviews
+// not even that.
+type PublicCompanyReview struct {
+	ID               int        `json:"id"`
+	CompanyID        int        `json:"companyId"`
+	AuthorName       string     `json:"authorName"`
+	OverallRating    int        `json:"overallRating"`
+	CultureRating    int        `json:"cultureRating"`
+	PayRating        int        `json:"payRating"`
+	ManagementRating int        `json:"managementRating"`
+	Pros             string     `json:"pros"`
+	Cons             string     `json:"cons"`
+	Reply            string     `json:"reply,omitempty"`
+	RepliedAt        *time.Time `json:"repliedAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+type CompanyRatingSummary struct {
+	CompanyID         int         `json:"companyId"`
+	ReviewCount       int         `json:"reviewCount"`
+	AverageOverall    float64     `json:"averageOverall"`
+	AverageCulture    float64     `json:"averageCulture"`
+	AveragePay        float64     `json:"averagePay"`
+	AverageManagement float64     `json:"averageManagement"`
+	Distribution      map[int]int `json:"distribution"`
+}
+
 type UserRepository interface {
 	GetUserByEmail(e string) (*User, error)
+	GetUserByID(id int) (*User, error)
 	CreateUser(u *User) (int, error)
 }
 
 type JobSeekerRepository interface {
 	CreateJobSeeker(js *JobSeeker) error
+	GetJobSeekerByUserID(userID int) (*JobSeeker, error)
+	UpdateJobSeeker(js *JobSeeker) error
+}
+
+type PositionRepository interface {
+	GetPositionsByJobSeekerID(jobSeekerID int) ([]Position, error)
+	GetPositionByID(id int) (*Position, error)
+	CreatePosition(p *Position) error
+	UpdatePosition(p *Position) error
+	DeletePosition(p *Position) error
+	RefreshExperience() error
+}
+
+type EducationRepository interface {
+	GetEducationByJobSeekerID(jobSeekerID int) ([]Education, error)
+	GetEducationByID(id int) (*Education, error)
+	CreateEducation(e *Education) error
+	UpdateEducation(e *Education) error
+	DeleteEducation(e *Education) error
+}
+
+type SkillRepository interface {
+	SearchSkills(query string, category string, limit int) ([]Skill, error)
+	GetSkillsByJobSeekerID(jobSeekerID int) ([]Skill, error)
+	GetSkillTerms() ([]SkillTerm, error)
+}
+
+type NotificationRepository interface {
+	CreateNotification(n *Notification) error
+	GetNotificationsByUserID(userID int, unreadOnly bool, limit, offset int) ([]Notification, error)
+	CountUnreadNotifications(userID int) (int, error)
+	MarkNotificationRead(userID, notificationID int) error
+	MarkAllNotificationsRead(userID int) error
+	GetNotificationPreferences(userID int) ([]NotificationPreference, error)
+	GetNotificationPreference(userID int, eventType string) (*NotificationPreference, error)
+	UpsertNotificationPreference(userID int, p *NotificationPreference) error
+	CreateDigestItem(n *Notification) error
+	GetPendingDigestItems() ([]Notification, error)
+	MarkDigestItemsSent(ids []int) error
+}
+
+// Notifier is how domain code emits notifications without depending on the
+// delivery channels.
+type Notifier interface {
+	Notify(n *Notification) error
+	NotifyAsync(n *Notification)
+}
+
+type PrivacyRepository interface {
+	GetPrivacySettings(jobSeekerID int) (*PrivacySettings, error)
+	UpdatePrivacySettings(jobSeekerID int, settings *PrivacySettings) error
+	GetBlocks(jobSeekerID int) ([]JobSeekerBlock, error)
+	CreateBlock(b *JobSeekerBlock) error
+	DeleteBlock(jobSeekerID, blockID int) error
+	IsVisibleToCompany(jobSeekerID, companyID int) (bool, error)
+}
+
+type TalentRepository interface {
+	SearchTalent(companyID int, q *TalentSearchQuery) ([]TalentResult, error)
+}
+
+type TalentPoolRepository interface {
+	GetPools(companyID int) ([]TalentPool, error)
+	GetPool(companyID, poolID int) (*TalentPool, error)
+	CreatePool(p *TalentPool) error
+	RenamePool(companyID, poolID int, name string) error
+	DeletePool(companyID, poolID int) error
+	GetPoolCandidates(p *TalentPool, tag string, limit, offset int) ([]PoolCandidate, error)
+	AddCandidate(poolID, jobSeekerID, addedBy int) error
+	RemoveCandidate(poolID, jobSeekerID int) error
+	GetTags(companyID, jobSeekerID int) ([]string, error)
+	SetTags(companyID, jobSeekerID int, tags []string) error
+	GetNotes(companyID, jobSeekerID int) ([]CandidateNote, error)
+	CreateNote(n *CandidateNote) error
 }
 
 type CompanyRepository interface {
 	CreateCompany(cpy *Company) error
+	GetCompanyByID(id int) (*Company, error)
+	GetCompanyProfileByID(id int) (*CompanyProfile, error)
+	GetCompanyProfiles(industry, size string, limit, offset int) ([]CompanyProfile, error)
+	UpdateCompany(cpy *Company) error
+}
+
+type CompanyMemberRepository interface {
+	GetMember(companyID, userID int) (*CompanyMember, error)
+	GetMembershipByUserID(userID int) (*CompanyMember, error)
+	GetMembers(companyID int) ([]CompanyMember, error)
+	UpdateMemberRole(companyID, userID int, role string) error
+	RemoveMember(companyID, userID int) error
+	TransferOwnership(companyID, fromUserID, toUserID int) error
+	CreateInvitation(inv *CompanyInvitation, tokenHash string) error
+	GetPendingInvitations(companyID int) ([]CompanyInvitation, error)
+	GetInvitationByTokenHash(tokenHash string) (*CompanyInvitation, error)
+	AcceptInvitation(inv *CompanyInvitation, passwordHash string) (int, error)
+	RevokeInvitation(companyID, invitationID int) error
+}
+
+type CompanyReviewRepository interface {
+	CreateReview(rev *CompanyReview) error
+	GetReviewByID(id int) (*CompanyReview, error)
+	GetPublicReviewsByCompanyID(companyID int, limit, offset int) ([]PublicCompanyReview, error)
+	GetReviewsByStatus(status string, limit, offset int) ([]CompanyReview, error)
+	ModerateReview(id int, status string, note string, moderatorID int) error
+	SetReviewReply(id int, reply string) error
+	GetRatingSummary(companyID int) (*CompanyRatingSummary, error)
 }
 
 type UserRequest struct {
@@ -64,6 +386,7 @@ type JobSeekerRequest struct {
 	Skills         []string `json:"skills,omitempty"`
 	Experience     int      `json:"experience,omitempty"`
 	Education      string   `json:"education,omitempty"`
+	Location       string   `json:"location,omitempty"`
 }
 
 type CompanyRequest struct {
@@ -81,6 +404,136 @@ type RegisterUserRequest struct {
 	CompanyRequest
 }
 
+// UpdateProfileRequest is a user's profile after a PATCH /me merge patch
+// has been applied to it. Role is taken from the authenticated user and
+// selects which fields are allowed.
+type UpdateProfileRequest struct {
+	Role string `json:"-"`
+	JobSeekerRequest
+	CompanyRequest
+}
+
+// MeResponse is the authenticated user's account together with the profile
+// for their role.
+type MeResponse struct {
+	*User
+	JobSeeker   *JobSeeker `json:"jobSeeker,omitempty"`
+	Company     *Company   `json:"company,omitempty"`
+	CompanyRole string     `json:"companyRole,omitempty"`
+}
+
+// ResumeDraft holds profile suggestions parsed from an uploaded resume. It
+// is returned for review and never written to the JobSeeker row directly.
+type ResumeDraft struct {
+	ProfileSummary string   `json:"profileSummary"`
+	Skills         []string `json:"skills"`
+	Experience     int      `json:"experience"`
+	Education      string   `json:"education"`
+}
+
+type NotificationPreferenceRequest struct {
+	InApp  *bool `json:"inApp"  validate:"required"`
+	Email  *bool `json:"email"  validate:"required"`
+	Digest *bool `json:"digest" validate:"required"`
+}
+
+type UnreadCountResponse struct {
+	Unread int `json:"unread"`
+}
+
+type CreateCompanyReviewRequest struct {
+	OverallRating    int    `json:"overallRating"    validate:"required,min=1,max=5"`
+	CultureRating    int    `json:"cultureRating"    validate:"required,min=1,max=5"`
+	PayRating        int    `json:"payRating"        validate:"required,min=1,max=5"`
+	ManagementRating int    `json:"managementRating" validate:"required,min=1,max=5"`
+	Pros             string `json:"pros"             validate:"max=2000"`
+	Cons             string `json:"cons"             validate:"max=2000"`
+	IsAnonymous      bool   `json:"isAnonymous"`
+}
+
+type PositionRequest struct {
+	Title       string   `json:"title"       validate:"required,max=255"`
+	Employer    string   `json:"employer"    validate:"required,max=255"`
+	StartDate   string   `json:"startDate"   validate:"required,datetime=2006-01-02"`
+	EndDate     *string  `json:"endDate"     validate:"omitnil,datetime=2006-01-02"`
+	Description string   `json:"description" validate:"max=2000"`
+	Skills      []string `json:"skills"      validate:"max=30,dive,required,max=100"`
+}
+
+type EducationRequest struct {
+	Institution  string  `json:"institution"  validate:"required,max=255"`
+	Degree       string  `json:"degree"       validate:"max=255"`
+	FieldOfStudy string  `json:"fieldOfStudy" validate:"max=255"`
+	StartDate    *string `json:"startDate"    validate:"omitnil,datetime=2006-01-02"`
+	EndDate      *string `json:"endDate"      validate:"omitnil,datetime=2006-01-02"`
+}
+
+// UpdateCompanyRequest edits a company profile. Omitted fields are left
+// unchanged.
+type UpdateCompanyRequest struct {
+	Name         *string `json:"name"         validate:"omitnil,min=1,max=255"`
+	Headquarters *string `json:"headquarters" validate:"omitnil,max=255"`
+	Website      *string `json:"website"      validate:"omitnil,max=255"`
+	Industry     *string `json:"industry"     validate:"omitnil,max=255"`
+	CompanySize  *string `json:"companySize"  validate:"omitnil,max=50"`
+}
+
+// UpdatePrivacyRequest changes privacy settings. Omitted fields are left
+// unchanged.
+type UpdatePrivacyRequest struct {
+	Visibility *string `json:"visibility" validate:"omitnil,oneof=public companies hidden"`
+	OpenToWork *bool   `json:"openToWork"`
+}
+
+// CreateBlockRequest blocks either a company or an email domain.
+type CreateBlockRequest struct {
+	CompanyID   int    `json:"companyId"   validate:"required_without=EmailDomain,excluded_with=EmailDomain"`
+	EmailDomain string `json:"emailDomain" validate:"omitempty,fqdn,max=255"`
+}
+
+type TalentPoolRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type AddCandidateRequest struct {
+	JobSeekerID int `json:"jobSeekerId" validate:"required"`
+}
+
+type SetTagsRequest struct {
+	Tags []string `json:"tags" validate:"max=20,dive,required,max=50"`
+}
+
+type CreateNoteRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+type CreateInvitationRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	Role  string `json:"role"  validate:"required,oneof=admin recruiter viewer"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token"    validate:"required"`
+	Password string `json:"password" validate:"required,min=6,max=200"`
+}
+
+type UpdateMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin recruiter viewer"`
+}
+
+type TransferOwnershipRequest struct {
+	UserID int `json:"userId" validate:"required"`
+}
+
+type ModerateReviewRequest struct {
+	Status string `json:"status" validate:"required,oneof=Approved Rejected"`
+	Note   string `json:"note"   validate:"max=255"`
+}
+
+type ReviewReplyRequest struct {
+	Reply string `json:"reply" validate:"required,max=2000"`
+}
+
 type LoginUserRequest struct {
 	Email    string `json:"email"    validate:"required,email"`
 	Password string `json:"password" validate:"required"`
@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AyKrimino/JobSeekerAPI/types"
 	"github.com/go-playground/validator/v10"
@@ -15,8 +18,13 @@ import (
 var Validate = validator.New()
 var IsAlpha = regexp.MustCompile(`^[a-zA-Z]+$`).MatchString
 
+// IsCompanyName allows letters, digits, spaces and the punctuation common in
+// company names, such as "Acme Corp." or "Procter & Gamble".
+var IsCompanyName = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} &'.,()+-]*$`).MatchString
+
 func init() {
 	Validate.RegisterStructValidation(ValidateRegisterUserRequest, types.RegisterUserRequest{})
+	Validate.RegisterStructValidation(ValidateUpdateProfileRequest, types.UpdateProfileRequest{})
 }
 
 func WriteJSON(w http.ResponseWriter, status int, v any) error {
@@ -37,6 +45,68 @@ func ParseJSON(r *http.Request, v any) error {
 	return json.NewDecoder(r.Body).Decode(v)
 }
 
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ParsePagination reads the 1-based page and pageSize query parameters and
+// returns the matching LIMIT and OFFSET values.
+func ParsePagination(r *http.Request) (limit int, offset int, err error) {
+	page, pageSize := 1, DefaultPageSize
+
+	if p := r.URL.Query().Get("page"); p != "" {
+		page, err = strconv.Atoi(p)
+		if err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("page must be a positive integer")
+		}
+	}
+
+	if ps := r.URL.Query().Get("pageSize"); ps != "" {
+		pageSize, err = strconv.Atoi(ps)
+		if err != nil || pageSize < 1 || pageSize > MaxPageSize {
+			return 0, 0, fmt.Errorf("pageSize must be between 1 and %d", MaxPageSize)
+		}
+	}
+
+	return pageSize, (page - 1) * pageSize, nil
+}
+
+// MergePatch applies an RFC 7386 JSON Merge Patch to doc: objects are merged
+// recursively, null removes a member and any other value replaces it.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target, p any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+
+	return json.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any)
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+
+	return t
+}
+
 func EncodeStringSliceToJSON(s []string) ([]byte, error) {
 	jsonData, err := json.Marshal(s)
 	if err != nil {
@@ -54,176 +124,273 @@ func DecodeJSONTOStringSlice(jsonData []byte) ([]string, error) {
 	return result, nil
 }
 
+// MonthRange is a span of months numbered by MonthNumber. Both ends are
+// included, so a January to December role covers 12 months.
+type MonthRange struct {
+	Start int
+	End   int
+}
+
+func MonthNumber(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
+// CoveredMonths returns the number of months covered by ranges, counting
+// overlapping and adjacent ranges once. Ranges ending before they start are
+// ignored.
+func CoveredMonths(ranges []MonthRange) int {
+	valid := make([]MonthRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.End >= r.Start {
+			valid = append(valid, r)
+		}
+	}
+
+	sort.Slice(valid, func(i, j int) bool { return valid[i].Start < valid[j].Start })
+
+	months := 0
+	for i := 0; i < len(valid); {
+		cur := valid[i]
+		j := i + 1
+		for ; j < len(valid) && valid[j].Start <= cur.End+1; j++ {
+			if valid[j].End > cur.End {
+				cur.End = valid[j].End
+			}
+		}
+		months += cur.End - cur.Start + 1
+		i = j
+	}
+
+	return months
+}
+
+// EscapeLike escapes the LIKE wildcards in s so it matches literally.
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// IsValidURL reports whether u is an http(s) URL with a host. A URL without
+// a scheme, such as "www.acme.com", is read as https.
 func IsValidURL(u string) bool {
-	_, err := url.ParseRequestURI(u)
-	return err != nil
+	if !strings.Contains(u, "://") {
+		u = "https://" + u
+	}
+
+	parsed, err := url.ParseRequestURI(u)
+	return err == nil &&
+		(parsed.Scheme == "http" || parsed.Scheme == "https") &&
+		strings.Contains(parsed.Host, ".")
 }
 
 func ValidateRegisterUserRequest(sl validator.StructLevel) {
 	req := sl.Current().Interface().(types.RegisterUserRequest)
 
-	if strings.EqualFold(req.Role, "JobSeeker") {
-		if req.FirstName == "" {
-			sl.ReportError(req.FirstName, "FirstName", "firstName", "required_for_jobseeker", "")
-		}
-		if !IsAlpha(req.FirstName) {
-			sl.ReportError(req.FirstName, "FirstName", "firstName", "firstName_not_alpha", "")
-		}
-		if len(req.FirstName) > 100 {
-			sl.ReportError(
-				req.FirstName,
-				"FirstName",
-				"firstName",
-				"firstName_length_must_be_lte_100",
-				"",
-			)
-		}
+	validateRoleFields(sl, req.Role, req.JobSeekerRequest, req.CompanyRequest)
+}
 
-		if req.LastName == "" {
-			sl.ReportError(req.LastName, "LastName", "lastName", "required_for_jobseeker", "")
-		}
-		if !IsAlpha(req.LastName) {
-			sl.ReportError(req.LastName, "LastName", "lastName", "lastName_not_alpha", "")
-		}
-		if len(req.LastName) > 100 {
-			sl.ReportError(
-				req.LastName,
-				"LastName",
-				"lastName",
-				"lastName_length_must_be_lte_100",
-				"",
-			)
-		}
+// ValidateUpdateProfileRequest applies the registration rules for the
+// user's role to a profile after a patch has been merged into it.
+func ValidateUpdateProfileRequest(sl validator.StructLevel) {
+	req := sl.Current().Interface().(types.UpdateProfileRequest)
 
-		if len(req.ProfileSummary) > 500 {
-			sl.ReportError(
-				req.ProfileSummary,
-				"ProfileSummary",
-				"profileSummary",
-				"profileSummary_length_must_be_lte_500",
-				"",
-			)
-		}
+	validateRoleFields(sl, req.Role, req.JobSeekerRequest, req.CompanyRequest)
+}
 
-		if req.Experience > 50 || req.Experience < 0 {
-			sl.ReportError(
-				req.Experience,
-				"Experience",
-				"experience",
-				"experience_must_be_between_0_and_50",
-				"",
-			)
-		}
+func validateRoleFields(
+	sl validator.StructLevel,
+	role string,
+	js types.JobSeekerRequest,
+	cpy types.CompanyRequest,
+) {
+	if strings.EqualFold(role, "JobSeeker") {
+		validateJobSeekerFields(sl, js)
+		forbidCompanyFields(sl, cpy, "forbidden_for_jobseeker")
+	}
+	if strings.EqualFold(role, "Company") {
+		validateCompanyFields(sl, cpy)
+		forbidJobSeekerFields(sl, js, "forbidden_for_company")
+	}
+}
 
-		if len(req.Education) > 255 {
-			sl.ReportError(
-				req.Education,
-				"Education",
-				"education",
-				"education_length_must_be_lte_255",
-				"",
-			)
-		}
+func validateJobSeekerFields(sl validator.StructLevel, req types.JobSeekerRequest) {
+	if req.FirstName == "" {
+		sl.ReportError(req.FirstName, "FirstName", "firstName", "required_for_jobseeker", "")
+	}
+	if !IsAlpha(req.FirstName) {
+		sl.ReportError(req.FirstName, "FirstName", "firstName", "firstName_not_alpha", "")
+	}
+	if len(req.FirstName) > 100 {
+		sl.ReportError(
+			req.FirstName,
+			"FirstName",
+			"firstName",
+			"firstName_length_must_be_lte_100",
+			"",
+		)
+	}
 
-		if req.Name != "" {
-			sl.ReportError(req.Name, "Name", "name", "forbidden_for_jobseeker", "")
-		}
-		if req.Headquarters != "" {
-			sl.ReportError(
-				req.Headquarters,
-				"Headquarters",
-				"headquarters",
-				"forbidden_for_jobseeker",
-				"",
-			)
-		}
-		if req.Website != "" {
-			sl.ReportError(req.Website, "Website", "website", "forbidden_for_jobseeker", "")
-		}
-		if req.Industry != "" {
-			sl.ReportError(req.Industry, "Industry", "industry", "forbidden_for_jobseeker", "")
-		}
-		if req.CompanySize != "" {
-			sl.ReportError(
-				req.CompanySize,
-				"CompanySize",
-				"companySize",
-				"forbidden_for_jobseeker",
-				"",
-			)
-		}
+	if req.LastName == "" {
+		sl.ReportError(req.LastName, "LastName", "lastName", "required_for_jobseeker", "")
+	}
+	if !IsAlpha(req.LastName) {
+		sl.ReportError(req.LastName, "LastName", "lastName", "lastName_not_alpha", "")
+	}
+	if len(req.LastName) > 100 {
+		sl.ReportError(
+			req.LastName,
+			"LastName",
+			"lastName",
+			"lastName_length_must_be_lte_100",
+			"",
+		)
 	}
-	if strings.EqualFold(req.Role, "Company") {
-		if req.Name == "" {
-			sl.ReportError(req.Name, "Name", "name", "required_for_company", "")
-		}
-		if !IsAlpha(req.Name) {
-			sl.ReportError(req.Name, "Name", "name", "name_not_alpha", "")
-		}
-		if len(req.Name) > 255 {
-			sl.ReportError(req.Name, "Name", "name", "name_length_must_be_lte_255", "")
-		}
 
-		if len(req.Headquarters) > 255 {
-			sl.ReportError(
-				req.Headquarters,
-				"Headquarters",
-				"headquarters",
-				"headquarters_length_must_be_lte_255",
-				"",
-			)
-		}
+	if len(req.ProfileSummary) > 500 {
+		sl.ReportError(
+			req.ProfileSummary,
+			"ProfileSummary",
+			"profileSummary",
+			"profileSummary_length_must_be_lte_500",
+			"",
+		)
+	}
 
-		if len(req.Website) > 255 {
-			sl.ReportError(req.Website, "Website", "website", "website_length_must_be_lte_255", "")
-		}
-		if !IsValidURL(req.Website) {
-			sl.ReportError(req.Website, "Website", "website", "website_must_be_in_url_format", "")
-		}
+	if req.Experience > 50 || req.Experience < 0 {
+		sl.ReportError(
+			req.Experience,
+			"Experience",
+			"experience",
+			"experience_must_be_between_0_and_50",
+			"",
+		)
+	}
 
-		if len(req.Industry) > 255 {
-			sl.ReportError(
-				req.Industry,
-				"Industry",
-				"industry",
-				"industry_length_must_be_lte_255",
-				"",
-			)
-		}
+	if len(req.Education) > 255 {
+		sl.ReportError(
+			req.Education,
+			"Education",
+			"education",
+			"education_length_must_be_lte_255",
+			"",
+		)
+	}
 
-		if len(req.CompanySize) > 255 {
-			sl.ReportError(
-				req.CompanySize,
-				"CompanySize",
-				"companySize",
-				"companySize_length_must_be_lte_255",
-				"",
-			)
-		}
+	if len(req.Location) > 100 {
+		sl.ReportError(
+			req.Location,
+			"Location",
+			"location",
+			"location_length_must_be_lte_100",
+			"",
+		)
+	}
+}
 
-		if req.FirstName != "" {
-			sl.ReportError(req.FirstName, "FirstName", "firstName", "forbidden_for_company", "")
-		}
-		if req.LastName != "" {
-			sl.ReportError(req.LastName, "LastName", "lastName", "forbidden_for_company", "")
-		}
-		if req.ProfileSummary != "" {
-			sl.ReportError(
-				req.ProfileSummary,
-				"ProfileSummary",
-				"profileSummary",
-				"forbidden_for_company",
-				"",
-			)
-		}
-		if req.Skills != nil {
-			sl.ReportError(req.Skills, "Skills", "skills", "forbidden_for_company", "")
-		}
-		if req.Experience != 0 {
-			sl.ReportError(req.Experience, "Experience", "experience", "forbidden_for_company", "")
-		}
-		if req.Education != "" {
-			sl.ReportError(req.Education, "Education", "education", "forbidden_for_company", "")
-		}
+func forbidCompanyFields(sl validator.StructLevel, req types.CompanyRequest, tag string) {
+	if req.Name != "" {
+		sl.ReportError(req.Name, "Name", "name", tag, "")
+	}
+	if req.Headquarters != "" {
+		sl.ReportError(
+			req.Headquarters,
+			"Headquarters",
+			"headquarters",
+			tag,
+			"",
+		)
+	}
+	if req.Website != "" {
+		sl.ReportError(req.Website, "Website", "website", tag, "")
+	}
+	if req.Industry != "" {
+		sl.ReportError(req.Industry, "Industry", "industry", tag, "")
+	}
+	if req.CompanySize != "" {
+		sl.ReportError(
+			req.CompanySize,
+			"CompanySize",
+			"companySize",
+			tag,
+			"",
+		)
+	}
+}
+
+func validateCompanyFields(sl validator.StructLevel, req types.CompanyRequest) {
+	if req.Name == "" {
+		sl.ReportError(req.Name, "Name", "name", "required_for_company", "")
+	}
+	if req.Name != "" && !IsCompanyName(req.Name) {
+		sl.ReportError(req.Name, "Name", "name", "name_has_invalid_characters", "")
+	}
+	if len(req.Name) > 255 {
+		sl.ReportError(req.Name, "Name", "name", "name_length_must_be_lte_255", "")
+	}
+
+	if len(req.Headquarters) > 255 {
+		sl.ReportError(
+			req.Headquarters,
+			"Headquarters",
+			"headquarters",
+			"headquarters_length_must_be_lte_255",
+			"",
+		)
+	}
+
+	if len(req.Website) > 255 {
+		sl.ReportError(req.Website, "Website", "website", "website_length_must_be_lte_255", "")
+	}
+	if req.Website != "" && !IsValidURL(req.Website) {
+		sl.ReportError(req.Website, "Website", "website", "website_must_be_in_url_format", "")
+	}
+
+	if len(req.Industry) > 255 {
+		sl.ReportError(
+			req.Industry,
+			"Industry",
+			"industry",
+			"industry_length_must_be_lte_255",
+			"",
+		)
+	}
+
+	if len(req.CompanySize) > 50 {
+		sl.ReportError(
+			req.CompanySize,
+			"CompanySize",
+			"companySize",
+			"companySize_length_must_be_lte_50",
+			"",
+		)
+	}
+}
+
+func forbidJobSeekerFields(sl validator.StructLevel, req types.JobSeekerRequest, tag string) {
+	if req.FirstName != "" {
+		sl.ReportError(req.FirstName, "FirstName", "firstName", tag, "")
+	}
+	if req.LastName != "" {
+		sl.ReportError(req.LastName, "LastName", "lastName", tag, "")
+	}
+	if req.ProfileSummary != "" {
+		sl.ReportError(
+			req.ProfileSummary,
+			"ProfileSummary",
+			"profileSummary",
+			tag,
+			"",
+		)
+	}
+	if req.Skills != nil {
+		sl.ReportError(req.Skills, "Skills", "skills", tag, "")
+	}
+	if req.Experience != 0 {
+		sl.ReportError(req.Experience, "Experience", "experience", tag, "")
+	}
+	if req.Education != "" {
+		sl.ReportError(req.Education, "Education", "education", tag, "")
+	}
+	if req.Location != "" {
+		sl.ReportError(req.Location, "Location", "location", tag, "")
 	}
 }
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"remove member", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"replace array", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"nested merge", `{"a":{"b":"c","d":"e"}}`, `{"a":{"d":null,"f":"g"}}`, `{"a":{"b":"c","f":"g"}}`},
+		{"non-object patch", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"empty patch", `{"a":"b"}`, `{}`, `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatal("MergePatch failed:", err)
+			}
+
+			var gotV, wantV any
+			if err := json.Unmarshal(got, &gotV); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantV); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(gotV, wantV) {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMergePatch_InvalidPatch(t *testing.T) {
+	if _, err := MergePatch([]byte(`{}`), []byte(`{`)); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
+
+func TestCoveredMonths(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []MonthRange
+		want   int
+	}{
+		{"no ranges", nil, 0},
+		{"single month", []MonthRange{{Start: 5, End: 5}}, 1},
+		{"january to december", []MonthRange{{Start: 24240, End: 24251}}, 12},
+		{"overlapping", []MonthRange{{Start: 0, End: 11}, {Start: 6, End: 17}}, 18},
+		{"adjacent", []MonthRange{{Start: 0, End: 5}, {Start: 6, End: 11}}, 12},
+		{"gap", []MonthRange{{Start: 0, End: 2}, {Start: 10, End: 11}}, 5},
+		{"reversed range is ignored", []MonthRange{{Start: 10, End: 2}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CoveredMonths(tt.ranges); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIsValidURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://acme.com", true},
+		{"http://acme.com/about", true},
+		{"www.acme.com", true},
+		{"acme", false},
+		{"not a url", false},
+		{"ftp://acme.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := IsValidURL(tt.url); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIsCompanyName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Acme", true},
+		{"Acme Corp.", true},
+		{"Procter & Gamble", true},
+		{"Société Générale", true},
+		{"3M", true},
+		{" Acme", false},
+		{"Acme<script>", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCompanyName(tt.name); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}